@@ -0,0 +1,39 @@
+package internal
+
+import (
+	"github.com/snapp-incubator/go-symspell/pkg/items"
+	verbositypkg "github.com/snapp-incubator/go-symspell/pkg/verbosity"
+)
+
+// lookupAffixes adds the suggestions found by correcting the stems of the affix analyses of the phrase, and ranks
+// them with the suggestions of the whole phrase.
+func (s *SymSpell) lookupAffixes(
+	phrase string,
+	verbosity verbositypkg.Verbosity,
+	maxEditDistance int,
+	suggestions []items.SuggestItem,
+) []items.SuggestItem {
+	seen := make(map[string]bool, len(suggestions))
+	for _, suggestion := range suggestions {
+		seen[suggestion.Term] = true
+	}
+	for _, analysis := range s.affixes.Analyze(phrase) {
+		stemSuggestions, err := s.lookupWord(analysis.Stem, verbosity, maxEditDistance)
+		if err != nil {
+			continue
+		}
+		for _, stemSuggestion := range stemSuggestions {
+			suggestion := stemSuggestion
+			suggestion.Term = analysis.Attach(stemSuggestion.Term)
+			suggestion.Stem, suggestion.Prefix, suggestion.Suffix = stemSuggestion.Term, analysis.Prefix, analysis.Suffix
+			if seen[suggestion.Term] {
+				continue
+			}
+			seen[suggestion.Term] = true
+			suggestions = append(suggestions, suggestion)
+		}
+	}
+
+	s.sortByRank(phrase, suggestions)
+	return s.trimToVerbosity(suggestions, verbosity)
+}
@@ -0,0 +1,119 @@
+package internal
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/snapp-incubator/go-symspell/pkg/ignore"
+	"github.com/snapp-incubator/go-symspell/pkg/items"
+)
+
+// reChunk matches the whitespace separated chunks of a text.
+var reChunk = regexp.MustCompile(`\S+`)
+
+// reTextWord matches the words of a text before normalization, unlike reSplit it keeps diacritics, tatweel, digits
+// of any script and zero-width joiner variants inside a word, so that the normalizer sees the word as a whole.
+var reTextWord = regexp.MustCompile(
+	`(?:[\p{L}\p{N}\p{M}\x{0640}]+[\x{00ad}\x{200b}-\x{200d}\x{feff}]+)*[\p{L}\p{N}\p{M}\x{0640}]+` +
+		`(?:['’][\p{L}\p{N}\p{M}\x{0640}]+)?`,
+)
+
+// CorrectText corrects the words of a text and keeps everything between them, such as punctuation, spaces and
+// line breaks, as it is. Words are corrected one at a time with the rules of LookupCompound, so a word can be
+// split into several words but adjacent words are never combined. Ignored tokens are left unchanged.
+func (s *SymSpell) CorrectText(text string, maxEditDistance int) items.TextCorrection {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var corrected strings.Builder
+	corrected.Grow(len(text))
+	var edits []items.Edit
+	last, lastRune := 0, 0
+	for _, span := range s.textWords(text) {
+		start, end := span[0], span[1]
+		corrected.WriteString(text[last:start])
+		runeStart := lastRune + utf8.RuneCountInString(text[last:start])
+		runeEnd := runeStart + utf8.RuneCountInString(text[start:end])
+
+		word := text[start:end]
+		correction, distance := s.correctWord(word, maxEditDistance)
+		if correction != word {
+			edits = append(edits, items.Edit{
+				Original:   word,
+				Correction: correction,
+				Start:      start,
+				End:        end,
+				RuneStart:  runeStart,
+				RuneEnd:    runeEnd,
+				Distance:   distance,
+			})
+		}
+		corrected.WriteString(correction)
+		last, lastRune = end, runeEnd
+	}
+	corrected.WriteString(text[last:])
+	return items.TextCorrection{Text: corrected.String(), Edits: edits}
+}
+
+// textWords returns the byte spans of the words of a text, ignored words and the words of ignored chunks are left
+// out.
+func (s *SymSpell) textWords(text string) [][]int {
+	words := reTextWord.FindAllStringIndex(text, -1)
+	if s.ignoreRules == nil {
+		return words
+	}
+	var ignoredChunks [][]int
+	for _, chunk := range reChunk.FindAllStringIndex(text, -1) {
+		token := text[chunk[0]:chunk[1]]
+		if s.ignored(token) || s.ignored(ignore.TrimPunctuation(token)) {
+			ignoredChunks = append(ignoredChunks, chunk)
+		}
+	}
+	kept := words[:0]
+	for _, word := range words {
+		for len(ignoredChunks) > 0 && ignoredChunks[0][1] <= word[0] {
+			ignoredChunks = ignoredChunks[1:]
+		}
+		if (len(ignoredChunks) == 0 || word[1] <= ignoredChunks[0][0]) && !s.ignored(text[word[0]:word[1]]) {
+			kept = append(kept, word)
+		}
+	}
+	return kept
+}
+
+// correctWord corrects a single word, a correction differing from the word only by its normalization or case is
+// not an edit and the word is returned as it is.
+func (s *SymSpell) correctWord(word string, maxEditDistance int) (string, int) {
+	normalized := s.normalize(word)
+	terms := parseWords(normalized, s.PreserveCase, s.SplitWordBySpace, s.SplitWordAndNumber)
+	if len(terms) == 0 {
+		return word, 0
+	}
+	joined := strings.Join(terms, " ")
+	ignored := make([]bool, len(terms))
+	for i, term := range terms {
+		ignored[i] = s.ignored(term)
+	}
+	suggestion := s.lookupCompound(joined, terms, ignored, maxEditDistance)
+	if suggestion.Term == joined {
+		return word, 0
+	}
+	if s.PreserveCase {
+		return suggestion.Term, suggestion.Distance
+	}
+	return transferCase(word, suggestion.Term), suggestion.Distance
+}
+
+// transferCase applies the case of an upper case or capitalized word to its lower case correction.
+func transferCase(word, correction string) string {
+	first, _ := utf8.DecodeRuneInString(word)
+	switch {
+	case strings.ToUpper(word) == word && strings.ToLower(word) != word && utf8.RuneCountInString(word) > 1:
+		return strings.ToUpper(correction)
+	case unicode.IsUpper(first):
+		correctionFirst, size := utf8.DecodeRuneInString(correction)
+		return string(unicode.ToUpper(correctionFirst)) + correction[size:]
+	}
+	return correction
+}
@@ -0,0 +1,98 @@
+package internal
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/snapp-incubator/go-symspell/pkg/ignore"
+	"github.com/snapp-incubator/go-symspell/pkg/items"
+	"github.com/snapp-incubator/go-symspell/pkg/normalizer"
+	"github.com/snapp-incubator/go-symspell/pkg/options"
+)
+
+func TestCorrectText(t *testing.T) {
+	symSpell, _ := NewSymSpell(options.WithIgnoreRules(ignore.Default()))
+	symSpell.createDictionaryEntry("سلام", 100)
+	symSpell.createDictionaryEntry("خیابان", 200)
+	symSpell.createDictionaryEntry("آزادی", 50)
+	symSpell.createDictionaryEntry("پلاک", 30)
+	symSpell.createDictionaryEntry("steam", 4)
+	symSpell.createDictionaryEntry("snap", 20)
+	symSpell.createDictionaryEntry("plate", 30)
+	symSpell.createDictionaryEntry("13", 1000)
+
+	text := "سلام! خیابن آزادی،\nپلاک ۱۲  - Stream www.snapp.ir (خیابانآزادی)"
+	result := symSpell.CorrectText(text, 2)
+
+	want := "سلام! خیابان آزادی،\nپلاک ۱۲  - Steam www.snapp.ir (خیابان آزادی)"
+	if result.Text != want {
+		t.Errorf("Expected %q, got %q", want, result.Text)
+	}
+	wantEdits := []items.Edit{
+		{Original: "خیابن", Correction: "خیابان", Start: 10, End: 20, RuneStart: 6, RuneEnd: 11, Distance: 1},
+		{Original: "Stream", Correction: "Steam", Start: 51, End: 57, RuneStart: 30, RuneEnd: 36, Distance: 1},
+		{Original: "خیابانآزادی", Correction: "خیابان آزادی", Start: 72, End: 94, RuneStart: 51, RuneEnd: 62, Distance: 1},
+	}
+	if !reflect.DeepEqual(result.Edits, wantEdits) {
+		t.Errorf("Expected edits %v, got %v", wantEdits, result.Edits)
+	}
+	for _, edit := range result.Edits {
+		if text[edit.Start:edit.End] != edit.Original || string([]rune(text)[edit.RuneStart:edit.RuneEnd]) != edit.Original {
+			t.Errorf("Expected the offsets of %v to point at the original word", edit)
+		}
+	}
+
+	if result := symSpell.CorrectText("  سلام،  \n", 2); result.Text != "  سلام،  \n" || len(result.Edits) != 0 {
+		t.Errorf("Expected a correct text to be unchanged, got %v", result)
+	}
+
+	// Words matching the ignore rules are kept inside chunks that are not ignored
+	result = symSpell.CorrectText("plat:12 (plate=12)", 2)
+	wantEdits = []items.Edit{
+		{Original: "plat", Correction: "plate", Start: 0, End: 4, RuneStart: 0, RuneEnd: 4, Distance: 1},
+	}
+	if result.Text != "plate:12 (plate=12)" || !reflect.DeepEqual(result.Edits, wantEdits) {
+		t.Errorf("Expected numbers next to punctuation to be kept, got %v", result)
+	}
+}
+
+func TestCorrectTextWithDiacritics(t *testing.T) {
+	symSpell, _ := NewSymSpell(options.WithNormalizer(normalizer.Persian()))
+	symSpell.createDictionaryEntry("کتاب", 100)
+	symSpell.createDictionaryEntry("کتابی", 40)
+	symSpell.createDictionaryEntry("خوب", 80)
+
+	// Tashkil and tatweel are part of the word, a word that only differs by its normalization is kept as it is
+	text := "كِتَاب خـوب، كِتَبی"
+	result := symSpell.CorrectText(text, 2)
+	if want := "كِتَاب خـوب، کتابی"; result.Text != want {
+		t.Errorf("Expected %q, got %q", want, result.Text)
+	}
+	wantEdits := []items.Edit{
+		{Original: "كِتَبی", Correction: "کتابی", Start: 24, End: 36, RuneStart: 13, RuneEnd: 19, Distance: 1},
+	}
+	if !reflect.DeepEqual(result.Edits, wantEdits) {
+		t.Errorf("Expected edits %v, got %v", wantEdits, result.Edits)
+	}
+
+	if result := symSpell.CorrectText("كِتَاب خوب", 2); result.Text != "كِتَاب خوب" || len(result.Edits) != 0 {
+		t.Errorf("Expected a diacritized correct text to be unchanged, got %v", result)
+	}
+}
+
+func TestTransferCase(t *testing.T) {
+	tests := []struct {
+		word, correction, want string
+	}{
+		{word: "Stream", correction: "steam", want: "Steam"},
+		{word: "STREAM", correction: "steam", want: "STEAM"},
+		{word: "stream", correction: "steam", want: "steam"},
+		{word: "A", correction: "an", want: "An"},
+		{word: "خیابن", correction: "خیابان", want: "خیابان"},
+	}
+	for _, test := range tests {
+		if got := transferCase(test.word, test.correction); got != test.want {
+			t.Errorf("transferCase(%q, %q) = %q, want %q", test.word, test.correction, got, test.want)
+		}
+	}
+}
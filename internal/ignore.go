@@ -0,0 +1,41 @@
+package internal
+
+import (
+	"strings"
+
+	"github.com/snapp-incubator/go-symspell/pkg/ignore"
+)
+
+// ignored reports whether a token matches the ignore rules.
+func (s *SymSpell) ignored(token string) bool {
+	return s.ignoreRules != nil && s.ignoreRules.Match(token)
+}
+
+// parseTerms splits a phrase into the terms of LookupCompound and reports which of them are ignored. With ignore
+// rules, the phrase is split on whitespace first so that URLs, emails and numbers are matched as a whole, chunks
+// that are not ignored are normalized and split into words, which are matched against the rules again.
+func (s *SymSpell) parseTerms(phrase string) ([]string, []bool) {
+	if s.ignoreRules == nil {
+		terms := parseWords(s.normalize(phrase), s.PreserveCase, s.SplitWordBySpace, s.SplitWordAndNumber)
+		return terms, make([]bool, len(terms))
+	}
+	var terms []string
+	var ignored []bool
+	for _, chunk := range strings.Fields(phrase) {
+		token := chunk
+		if !s.ignored(token) {
+			token = ignore.TrimPunctuation(chunk)
+		}
+		if s.ignored(token) {
+			terms = append(terms, token)
+			ignored = append(ignored, true)
+			continue
+		}
+		// The terms of a chunk such as "plate:12" are matched on their own, so that its number is kept as well
+		for _, term := range parseWords(s.normalize(chunk), s.PreserveCase, s.SplitWordBySpace, s.SplitWordAndNumber) {
+			terms = append(terms, term)
+			ignored = append(ignored, s.ignored(term))
+		}
+	}
+	return terms, ignored
+}
@@ -0,0 +1,85 @@
+package internal
+
+import (
+	"strings"
+
+	"github.com/snapp-incubator/go-symspell/pkg/items"
+)
+
+// zwnj is the zero width non-joiner, Persian compounds and plurals are written with it, with a space or with nothing.
+const zwnj = "\u200c"
+
+// joinerVariant returns the dictionary spelling of a term that differs from it only by its joiners: a ZWNJ inserted
+// between two parts, a ZWNJ removed, or a ZWNJ replaced by a space when every part is a word. Changing a joiner is
+// not counted as an edit.
+func (s *SymSpell) joinerVariant(term string) (items.SuggestItem, bool) {
+	if strings.Contains(term, zwnj) {
+		joined := strings.ReplaceAll(term, zwnj, "")
+		if count, found := s.Words[joined]; found {
+			return items.SuggestItem{Term: joined, Count: count}, true
+		}
+		parts := strings.Split(term, zwnj)
+		count := s.N
+		for _, part := range parts {
+			partCount, found := s.Words[part]
+			if !found {
+				return items.SuggestItem{}, false
+			}
+			count *= float64(partCount) / s.N
+		}
+		separated := strings.Join(parts, " ")
+		if bigramCount, found := s.Bigrams[separated]; found {
+			count = float64(bigramCount)
+		}
+		return items.SuggestItem{Term: separated, Count: int(count)}, true
+	}
+
+	var best items.SuggestItem
+	runes := []rune(term)
+	for j := 1; j < len(runes); j++ {
+		joined := string(runes[:j]) + zwnj + string(runes[j:])
+		if count, found := s.Words[joined]; found && count > best.Count {
+			best = items.SuggestItem{Term: joined, Count: count}
+		}
+	}
+	return best, best.Term != ""
+}
+
+// joinedWord returns the dictionary word spelling two adjacent terms as one, joined by a ZWNJ or written together.
+func (s *SymSpell) joinedWord(left, right string) (items.SuggestItem, bool) {
+	var best items.SuggestItem
+	for _, joined := range []string{left + zwnj + right, left + right} {
+		if count, found := s.Words[joined]; found && count > best.Count {
+			best = items.SuggestItem{Term: joined, Count: count}
+		}
+	}
+	return best, best.Term != ""
+}
+
+// combineWithJoiner merges the previous term into the current one when the dictionary spells them, or their
+// corrections, as a single word, unless both are words that are more likely to appear apart.
+func (s *SymSpell) combineWithJoiner(cp *compoundProcessor, maxEditDistance int) bool {
+	best1 := cp.suggestionParts[len(cp.suggestionParts)-1]
+	best2 := s.getBestSuggestion2(*cp, maxEditDistance)
+	// The previous term is joined as it was transformed, an abbreviation is never glued to the next word
+	combined, found := s.joinedWord(s.replaceExactMatch(cp.terms2), cp.terms1)
+	corrected := !found
+	if corrected {
+		if combined, found = s.joinedWord(best1.Term, best2.Term); !found {
+			return false
+		}
+		combined.Distance = best1.Distance + best2.Distance
+	}
+	if corrected || best1.Distance+best2.Distance == 0 {
+		if count, found := s.Bigrams[best1.Term+" "+best2.Term]; found && count > combined.Count {
+			return false
+		}
+		if float64(combined.Count) <= float64(best1.Count)/s.N*float64(best2.Count) {
+			return false
+		}
+	}
+	cp.suggestionParts[len(cp.suggestionParts)-1] = combined
+	cp.replacedWords[cp.terms2] = combined
+	cp.isLastCombi = true
+	return true
+}
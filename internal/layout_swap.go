@@ -0,0 +1,47 @@
+package internal
+
+import (
+	"github.com/snapp-incubator/go-symspell/pkg/editdistance"
+	"github.com/snapp-incubator/go-symspell/pkg/items"
+	verbositypkg "github.com/snapp-incubator/go-symspell/pkg/verbosity"
+)
+
+// layoutSwapCandidates maps the phrase from every configured layout it was fully typed on to the other layouts.
+func (s *SymSpell) layoutSwapCandidates(phrase string) []string {
+	var candidates []string
+	for _, from := range s.layoutSwaps {
+		for _, to := range s.layoutSwaps {
+			if from == to {
+				continue
+			}
+			if swapped, ok := editdistance.SwapLayout(phrase, from, to); ok && swapped != phrase {
+				candidates = append(candidates, swapped)
+			}
+		}
+	}
+	return candidates
+}
+
+// lookupLayoutSwap replaces the suggestions of the phrase with those of a layout swapped candidate when they are
+// closer, the replaced suggestions are flagged with LayoutSwapped.
+func (s *SymSpell) lookupLayoutSwap(
+	phrase string,
+	verbosity verbositypkg.Verbosity,
+	maxEditDistance int,
+	suggestions []items.SuggestItem,
+) []items.SuggestItem {
+	for _, candidate := range s.layoutSwapCandidates(phrase) {
+		swappedSuggestions, err := s.lookupWord(candidate, verbosity, maxEditDistance)
+		if err != nil || len(swappedSuggestions) == 0 {
+			continue
+		}
+		if len(suggestions) > 0 && swappedSuggestions[0].Distance >= suggestions[0].Distance {
+			continue
+		}
+		for i := range swappedSuggestions {
+			swappedSuggestions[i].LayoutSwapped = true
+		}
+		suggestions = swappedSuggestions
+	}
+	return suggestions
+}
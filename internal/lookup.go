@@ -5,17 +5,89 @@ import (
 	"slices"
 	"sort"
 
+	"github.com/snapp-incubator/go-symspell/pkg/editdistance"
 	"github.com/snapp-incubator/go-symspell/pkg/items"
 	verbositypkg "github.com/snapp-incubator/go-symspell/pkg/verbosity"
 )
 
+// Lookup finds suggestions for a single word, it is safe to call concurrently with other lookups
+// and with dictionary updates.
 func (s *SymSpell) Lookup(
 	phrase string,
 	verbosity verbositypkg.Verbosity,
 	maxEditDistance int,
+) ([]items.SuggestItem, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.ignored(phrase) {
+		return []items.SuggestItem{{Term: phrase, Distance: 0, Count: s.Words[phrase], Ignored: true}}, nil
+	}
+	return s.lookup(s.normalize(phrase), verbosity, maxEditDistance)
+}
+
+var errDistanceTooLarge = errors.New("distance too large")
+
+// lookup is Lookup without locking, callers must hold the read lock.
+func (s *SymSpell) lookup(
+	phrase string,
+	verbosity verbositypkg.Verbosity,
+	maxEditDistance int,
+) ([]items.SuggestItem, error) {
+	var collapsed []items.SuggestItem
+	// Collapsing removes letters, a lookup without edits only finds exact matches
+	if s.collapseRepeats && maxEditDistance > 0 && maxEditDistance <= s.MaxDictionaryEditDistance {
+		collapsed = s.lookupCollapsed(phrase, verbosity)
+		// The collapsed words are preferred to the words at an edit distance, unless every suggestion is asked for
+		if len(collapsed) > 0 && verbosity != verbositypkg.All {
+			return collapsed, nil
+		}
+	}
+	suggestions, err := s.lookupWord(phrase, verbosity, maxEditDistance)
+	if err != nil || isExactMatch(suggestions) {
+		return suggestions, err
+	}
+	if len(collapsed) > 0 {
+		suggestions = s.mergeCollapsed(phrase, suggestions, collapsed)
+	}
+	if s.skeletonCost > 0 {
+		suggestions = s.lookupSkeleton(phrase, verbosity, maxEditDistance, suggestions)
+	}
+	if s.affixes != nil {
+		suggestions = s.lookupAffixes(phrase, verbosity, maxEditDistance, suggestions)
+	}
+	if s.phoneticIndex {
+		suggestions = s.lookupPhonetic(phrase, verbosity, suggestions)
+	}
+	if len(s.layoutSwaps) == 0 || isExactMatch(suggestions) {
+		return suggestions, nil
+	}
+	return s.lookupLayoutSwap(phrase, verbosity, maxEditDistance, suggestions), nil
+}
+
+func isExactMatch(suggestions []items.SuggestItem) bool {
+	return len(suggestions) > 0 && suggestions[0].Distance == 0
+}
+
+// lookupWord dispatches to the ranked lookup when a weighted or keyboard distance is configured.
+func (s *SymSpell) lookupWord(
+	phrase string,
+	verbosity verbositypkg.Verbosity,
+	maxEditDistance int,
+) ([]items.SuggestItem, error) {
+	if s.weightedComparer != nil || s.keyboardComparer != nil {
+		return s.lookupRanked(phrase, verbosity, maxEditDistance)
+	}
+	return s.lookupCandidates(phrase, verbosity, maxEditDistance)
+}
+
+// lookupCandidates searches the deletes for suggestions within maxEditDistance.
+func (s *SymSpell) lookupCandidates(
+	phrase string,
+	verbosity verbositypkg.Verbosity,
+	maxEditDistance int,
 ) ([]items.SuggestItem, error) {
 	if maxEditDistance > s.MaxDictionaryEditDistance {
-		return nil, errors.New("distance too large")
+		return nil, errDistanceTooLarge
 	}
 	cp := newCandidateProcessor(maxEditDistance, verbosity, phrase)
 	// Early exit - word too big to match any words
@@ -237,6 +309,10 @@ func (s *SymSpell) addEditDistance(candidateRunes []rune, cp *candidateProcessor
 }
 
 func (s *SymSpell) distanceCompare(a, b string, maxDistance int) int {
+	// Bounded comparers stop as soon as maxDistance is exceeded
+	if comparer, ok := s.distanceComparer.(editdistance.IBoundedEditDistance); ok {
+		return comparer.DistanceMax(a, b, maxDistance)
+	}
 	distance := s.distanceComparer.Distance(a, b)
 
 	// Check if the distance exceeds the maxDistance
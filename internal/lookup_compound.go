@@ -3,14 +3,17 @@ package internal
 import (
 	"bufio"
 	"fmt"
+	"io"
+	"io/fs"
 	"math"
-	"os"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"unicode"
 
 	"github.com/snapp-incubator/go-symspell/pkg/items"
+	"github.com/snapp-incubator/go-symspell/pkg/loadresult"
 	verbositypkg "github.com/snapp-incubator/go-symspell/pkg/verbosity"
 )
 
@@ -30,10 +33,20 @@ func parseWords(phrase string, preserveCase, splitBySpace, splitNumber bool) []s
 	return reSplit.FindAllString(phrase, -1)
 }
 
-var reSplit = regexp.MustCompile(`([\p{L}\d]+(?:['’][\p{L}\d]+)?)`)
+// reSplit keeps words joined by a ZWNJ, such as Persian compounds and plurals, as a single term
+var reSplit = regexp.MustCompile(`((?:[\p{L}\d]+\x{200c})*[\p{L}\d]+(?:['’][\p{L}\d]+)?)`)
 
 func (s *SymSpell) LookupCompound(phrase string, maxEditDistance int) *items.SuggestItem {
-	terms1 := parseWords(phrase, s.PreserveCase, s.SplitWordBySpace, s.SplitWordAndNumber)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	terms, ignored := s.parseTerms(phrase)
+	return s.lookupCompound(phrase, terms, ignored, maxEditDistance)
+}
+
+// lookupCompound corrects the terms parsed from a phrase, callers must hold the read lock.
+func (s *SymSpell) lookupCompound(phrase string, terms1 []string, ignored []bool, maxEditDistance int) *items.SuggestItem {
+	input := slices.Clone(terms1)
+	transliterated := s.transliterate(terms1, ignored)
 	cp := compoundProcessor{
 		suggestions:     make([]items.SuggestItem, 0),
 		suggestionParts: make([]items.SuggestItem, 0),
@@ -41,12 +54,22 @@ func (s *SymSpell) LookupCompound(phrase string, maxEditDistance int) *items.Sug
 		isLastCombi:     false,
 	}
 	for i := range terms1 {
+		cp.index = i
+		if ignored[i] {
+			// Ignored terms are kept as they are and never combined with their neighbours
+			cp.addPart(items.SuggestItem{Term: terms1[i], Count: int(s.N), Ignored: true})
+			cp.isLastCombi = true
+			continue
+		}
 		cp.terms1 = s.replaceExactMatch(terms1[i])
 		s.getSuggestion(&cp, maxEditDistance)
 		// Combine adjacent terms
 		if i > 0 && !cp.isLastCombi {
 			cp.terms2 = terms1[i-1]
-			suggestionsCombi, _ := s.Lookup(fmt.Sprintf("%s %s", cp.terms2, cp.terms1), verbositypkg.Top, maxEditDistance)
+			if s.combineWithJoiner(&cp, maxEditDistance) {
+				continue
+			}
+			suggestionsCombi, _ := s.lookup(fmt.Sprintf("%s %s", cp.terms2, cp.terms1), verbositypkg.Top, maxEditDistance)
 			if len(suggestionsCombi) > 0 {
 				best1 := cp.suggestionParts[len(cp.suggestionParts)-1]
 				best2 := s.getBestSuggestion2(cp, maxEditDistance)
@@ -60,7 +83,7 @@ func (s *SymSpell) LookupCompound(phrase string, maxEditDistance int) *items.Sug
 
 		// Handle terms with no perfect suggestion
 		if len(cp.suggestions) > 0 && (cp.suggestions[0].Distance == 0 || len(cp.terms1) == 1) {
-			cp.suggestionParts = append(cp.suggestionParts, cp.suggestions[0])
+			cp.addPart(cp.suggestions[0])
 		} else {
 			var suggestionSplitBest *items.SuggestItem
 			if len(cp.suggestions) > 0 {
@@ -110,12 +133,23 @@ func (s *SymSpell) LookupCompound(phrase string, maxEditDistance int) *items.Sug
 		}
 	}
 
-	return s.finalizeAnswer(phrase, cp.suggestionParts)
+	if s.realWordRatio > 0 {
+		s.correctRealWords(terms1, &cp, maxEditDistance)
+	}
+	answer := s.finalizeAnswer(phrase, cp.suggestionParts)
+	answer.Transliterated = transliterated
+	answer.Alignment = s.alignParts(input, terms1, &cp)
+	return answer
 }
 
 func (s *SymSpell) getSuggestion(cp *compoundProcessor, maxEditDistance int) {
 	if len([]rune(cp.terms1)) > s.MinimumCharToChange {
-		cp.suggestions, _ = s.Lookup(cp.terms1, verbositypkg.Top, maxEditDistance)
+		cp.suggestions, _ = s.lookup(cp.terms1, verbositypkg.Top, maxEditDistance)
+		if len(cp.suggestions) == 0 || cp.suggestions[0].Distance > 0 {
+			if variant, found := s.joinerVariant(cp.terms1); found {
+				cp.suggestions = []items.SuggestItem{variant}
+			}
+		}
 	} else {
 		cp.suggestions = []items.SuggestItem{{
 			Term:     cp.terms1,
@@ -153,8 +187,8 @@ func (s *SymSpell) validateCombinationDistance(best1 items.SuggestItem, best2 it
 func (s *SymSpell) getSuggestions(runes []rune, split int, maxEditDistance int) (*items.SuggestItem, *items.SuggestItem, bool) {
 	part1 := string(runes[:split])
 	part2 := string(runes[split:])
-	suggestions1, _ := s.Lookup(part1, verbositypkg.Top, maxEditDistance)
-	suggestions2, _ := s.Lookup(part2, verbositypkg.Top, maxEditDistance)
+	suggestions1, _ := s.lookup(part1, verbositypkg.Top, maxEditDistance)
+	suggestions2, _ := s.lookup(part2, verbositypkg.Top, maxEditDistance)
 	if len(suggestions1) == 0 || len(suggestions2) == 0 {
 		return nil, nil, false
 	}
@@ -194,23 +228,69 @@ func (s *SymSpell) checkForBigram(cp *compoundProcessor) int {
 }
 
 func (c *compoundProcessor) updateReplaceWord(terms1 string, item items.SuggestItem) {
-	c.suggestionParts = append(c.suggestionParts, item)
+	c.addPart(item)
 	c.replacedWords[terms1] = item
 }
 
+// addPart appends a suggestion part for the current term, a part that is later combined with the next term
+// keeps its start.
+func (c *compoundProcessor) addPart(item items.SuggestItem) {
+	c.suggestionParts = append(c.suggestionParts, item)
+	c.partStarts = append(c.partStarts, c.index)
+}
+
+// alignParts maps the suggestion parts to the input terms they replaced. The input holds the terms as they were
+// parsed and terms the terms as they were looked up, after transliteration.
+func (s *SymSpell) alignParts(input, terms []string, cp *compoundProcessor) []items.Alignment {
+	alignment := make([]items.Alignment, len(cp.suggestionParts))
+	for k, part := range cp.suggestionParts {
+		start, end := cp.partStarts[k], len(input)
+		if k+1 < len(cp.partStarts) {
+			end = cp.partStarts[k+1]
+		}
+		aligned := items.Alignment{
+			Start:    start,
+			End:      end,
+			Input:    input[start:end],
+			Output:   strings.Fields(part.Term),
+			Distance: part.Distance,
+			Count:    part.Count,
+		}
+		transformed := s.replaceExactMatch(terms[start])
+		switch {
+		case end-start > 1:
+			aligned.Operation = items.Merge
+		case part.Ignored || part.Term == input[start]:
+			aligned.Operation = items.Keep
+			aligned.Distance = 0
+		case transformed != terms[start] && part.Term == transformed:
+			aligned.Operation = items.ExactTransform
+		case len(aligned.Output) > 1:
+			aligned.Operation = items.Split
+		default:
+			aligned.Operation = items.Replace
+		}
+		alignment[k] = aligned
+	}
+	return alignment
+}
+
 func (s *SymSpell) finalizeAnswer(phrase string, suggestionParts []items.SuggestItem) *items.SuggestItem {
 	joinedTerm := ""
 	joinedCount := s.N
+	layoutSwapped := false
 	for _, item := range suggestionParts {
 		joinedTerm += item.Term + " "
 		joinedCount *= float64(item.Count) / s.N
+		layoutSwapped = layoutSwapped || item.LayoutSwapped
 	}
 	joinedTerm = strings.TrimSpace(joinedTerm)
 
 	return &items.SuggestItem{
-		Term:     joinedTerm,
-		Distance: s.distanceCompare(phrase, joinedTerm, math.MaxInt32),
-		Count:    int(joinedCount),
+		Term:          joinedTerm,
+		Distance:      s.distanceCompare(phrase, joinedTerm, math.MaxInt32),
+		Count:         int(joinedCount),
+		LayoutSwapped: layoutSwapped,
 	}
 }
 
@@ -242,8 +322,26 @@ func tryParseInt64(value string) (int, bool) {
 	return parsed, true
 }
 
-// Load bigram dictionary from a stream
-func (s *SymSpell) LoadBigramDictionaryStream(corpusStream *os.File, termIndex, countIndex int, separator string) bool {
+// LoadBigramDictionaryStream loads bigrams from a stream.
+func (s *SymSpell) LoadBigramDictionaryStream(corpusStream io.Reader, termIndex, countIndex int, separator string) (bool, error) {
+	result := loadresult.NewDictionary(loadresult.Bigram, "")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err := s.loadBigramDictionaryStream(corpusStream, termIndex, countIndex, separator, &result)
+	return result.Loaded, err
+}
+
+func (s *SymSpell) loadBigramDictionaryStream(
+	corpusStream io.Reader,
+	termIndex, countIndex int,
+	separator string,
+	result *loadresult.Dictionary,
+) error {
+	corpusStream, err := decompressCorpus(corpusStream)
+	if err != nil {
+		result.Err = err
+		return err
+	}
 	scanner := bufio.NewScanner(corpusStream)
 
 	// Define minimum parts depending on the separator
@@ -255,6 +353,7 @@ func (s *SymSpell) LoadBigramDictionaryStream(corpusStream *os.File, termIndex,
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
 		if line == "" {
+			result.Skip(loadresult.EmptyLine)
 			continue
 		}
 
@@ -266,13 +365,15 @@ func (s *SymSpell) LoadBigramDictionaryStream(corpusStream *os.File, termIndex,
 			parts = strings.Split(line, separator)
 		}
 
-		if len(parts) < minParts {
+		if len(parts) < minParts || len(parts) <= max(countIndex, termIndex+1) {
+			result.Skip(loadresult.MissingFields)
 			continue
 		}
 
 		// Parse count
 		count, ok := tryParseInt64(parts[countIndex])
 		if !ok {
+			result.Skip(loadresult.InvalidCount)
 			continue
 		}
 
@@ -284,15 +385,21 @@ func (s *SymSpell) LoadBigramDictionaryStream(corpusStream *os.File, termIndex,
 			key = parts[termIndex]
 		}
 		// Add to bigram dictionary
-		s.Bigrams[key] = count
+		s.Bigrams[s.normalize(key)] = count
+		result.Accept()
 
 		// Update the minimum bigram count
 		if count < s.BigramCountMin {
 			s.BigramCountMin = count
 		}
 	}
+	if err := scanner.Err(); err != nil {
+		result.Err = err
+		return err
+	}
 
-	return true
+	result.Loaded = true
+	return nil
 }
 
 func (s *SymSpell) LoadBigramDictionary(
@@ -300,29 +407,62 @@ func (s *SymSpell) LoadBigramDictionary(
 	termIndex, countIndex int,
 	separator string,
 ) (bool, error) {
-	if corpusPath == "" {
-		return false, fmt.Errorf("corpus path cannot be empty")
-	}
-	// Check if the file exists
-	file, err := os.Open(corpusPath)
+	result, err := s.LoadBigramDictionaryWithResult(corpusPath, termIndex, countIndex, separator)
+	return result.Loaded, err
+}
+
+// LoadBigramDictionaryWithResult loads bigrams from a file and reports how many lines were accepted or skipped.
+func (s *SymSpell) LoadBigramDictionaryWithResult(
+	corpusPath string,
+	termIndex, countIndex int,
+	separator string,
+) (loadresult.Dictionary, error) {
+	return s.loadBigramDictionaryFile(nil, corpusPath, termIndex, countIndex, separator)
+}
+
+// LoadBigramDictionaryFS loads bigrams from a file of fsys, such as an embed.FS.
+func (s *SymSpell) LoadBigramDictionaryFS(
+	fsys fs.FS,
+	corpusPath string,
+	termIndex, countIndex int,
+	separator string,
+) (bool, error) {
+	result, err := s.loadBigramDictionaryFile(fsys, corpusPath, termIndex, countIndex, separator)
+	return result.Loaded, err
+}
+
+func (s *SymSpell) loadBigramDictionaryFile(
+	fsys fs.FS,
+	corpusPath string,
+	termIndex, countIndex int,
+	separator string,
+) (loadresult.Dictionary, error) {
+	result := loadresult.NewDictionary(loadresult.Bigram, corpusPath)
+	file, err := openCorpus(fsys, corpusPath)
 	if err != nil {
-		return false, err
+		result.Err = err
+		return result, err
 	}
 	defer file.Close()
 
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	// Use the stream-based loading function
-	return s.LoadBigramDictionaryStream(file, termIndex, countIndex, separator), nil
+	err = s.loadBigramDictionaryStream(file, termIndex, countIndex, separator, &result)
+	return result, err
 }
 
 type compoundProcessor struct {
 	suggestions     []items.SuggestItem
 	suggestionParts []items.SuggestItem
+	partStarts      []int
 	replacedWords   map[string]items.SuggestItem
 	terms1          string
 	terms2          string
 	suggestion1     items.SuggestItem
 	suggestion2     items.SuggestItem
 	isLastCombi     bool
+	index           int
 }
 
 func (c *compoundProcessor) tempTerm() string {
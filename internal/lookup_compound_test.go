@@ -4,9 +4,14 @@ import (
 	"encoding/json"
 	"os"
 	"reflect"
+	"regexp"
 	"testing"
 
+	"github.com/snapp-incubator/go-symspell/pkg/ignore"
+	"github.com/snapp-incubator/go-symspell/pkg/items"
 	"github.com/snapp-incubator/go-symspell/pkg/options"
+	"github.com/snapp-incubator/go-symspell/pkg/transliteration"
+	verbositypkg "github.com/snapp-incubator/go-symspell/pkg/verbosity"
 )
 
 type Entry struct {
@@ -84,6 +89,119 @@ func TestLookupCompound(t *testing.T) {
 	}
 }
 
+func TestLookupCompoundWithZWNJ(t *testing.T) {
+	symSpell, _ := NewSymSpell(options.WithMaxDictionaryEditDistance(2))
+	symSpell.createDictionaryEntry("نجف\u200cآباد", 100)
+	symSpell.createDictionaryEntry("نجف", 50)
+	symSpell.createDictionaryEntry("آباد", 40)
+	symSpell.createDictionaryEntry("خیابان\u200cها", 80)
+	symSpell.createDictionaryEntry("خیابان", 200)
+	symSpell.createDictionaryEntry("ها", 300)
+	symSpell.createDictionaryEntry("کتاب", 60)
+	symSpell.createDictionaryEntry("خانه", 70)
+	symSpell.createDictionaryEntry("آزادی", 90)
+
+	tests := []struct {
+		phrase string
+		term   string
+	}{
+		{phrase: "نجف\u200cآباد", term: "نجف\u200cآباد"},
+		{phrase: "نجف آباد", term: "نجف\u200cآباد"},
+		{phrase: "نجفآباد", term: "نجف\u200cآباد"},
+		{phrase: "خیابانها", term: "خیابان\u200cها"},
+		{phrase: "خیابان ها آزادی", term: "خیابان\u200cها آزادی"},
+		{phrase: "خیابن ها", term: "خیابان\u200cها"},
+		{phrase: "کتاب\u200cخانه", term: "کتاب خانه"},
+		{phrase: "خیابان آزادی", term: "خیابان آزادی"},
+	}
+	for _, test := range tests {
+		result := symSpell.LookupCompound(test.phrase, 2)
+		if result.Term != test.term {
+			t.Errorf("For phrase '%s', expected '%s', got '%s'", test.phrase, test.term, result.Term)
+		}
+	}
+}
+
+func TestLookupCompoundWithTransliterator(t *testing.T) {
+	symSpell, _ := NewSymSpell(options.WithTransliterator(transliteration.NewFinglish()))
+	symSpell.createDictionaryEntry("خیابان", 200)
+	symSpell.createDictionaryEntry("آزادی", 50)
+	symSpell.createDictionaryEntry("ازادی", 100)
+	symSpell.createDictionaryEntry("street", 10)
+	symSpell.Bigrams["خیابان آزادی"] = 20
+
+	tests := []struct {
+		phrase         string
+		term           string
+		transliterated bool
+	}{
+		// The bigram with the previous word outranks a more frequent spelling
+		{phrase: "khiaban azadi", term: "خیابان آزادی", transliterated: true},
+		{phrase: "azadi", term: "ازادی", transliterated: true},
+		{phrase: "azadi street", term: "ازادی street", transliterated: true},
+		{phrase: "street", term: "street", transliterated: false},
+	}
+	for _, test := range tests {
+		result := symSpell.LookupCompound(test.phrase, 2)
+		if result.Term != test.term || result.Transliterated != test.transliterated {
+			t.Errorf("For phrase '%s', expected '%s' transliterated %v, got %v",
+				test.phrase, test.term, test.transliterated, result)
+		}
+	}
+
+	// The alternatives are those of the transliterated terms
+	results := symSpell.LookupCompoundTopK("khiaban azadi", 3, verbositypkg.All, 2)
+	if len(results) < 2 || results[0].Term != "خیابان آزادی" || results[1].Term != "خیابان ازادی" ||
+		!results[1].Transliterated {
+		t.Errorf("Expected the alternatives of the transliterated terms, got %v", results)
+	}
+}
+
+func TestLookupCompoundWithIgnoreRules(t *testing.T) {
+	productCodes := regexp.MustCompile(`^(?i)sku-\d+$`)
+	symSpell, _ := NewSymSpell(options.WithIgnoreRules(ignore.Default(productCodes)))
+	symSpell.createDictionaryEntry("خیابان", 200)
+	symSpell.createDictionaryEntry("پلاک", 30)
+	symSpell.createDictionaryEntry("کد", 20)
+	symSpell.createDictionaryEntry("snap", 20)
+	symSpell.createDictionaryEntry("2", 1000)
+	symSpell.createDictionaryEntry("plate", 30)
+	symSpell.createDictionaryEntry("13", 1000)
+
+	tests := []struct {
+		phrase string
+		term   string
+	}{
+		{phrase: "خیابن پلاک 12", term: "خیابان پلاک 12"},
+		// Terms split out of a chunk are matched on their own
+		{phrase: "plate:12", term: "plate 12"},
+		{phrase: "(plate=12)", term: "plate 12"},
+		{phrase: "پلاک ۱۲", term: "پلاک ۱۲"},
+		{phrase: "کد 12345-67890", term: "کد 12345-67890"},
+		{phrase: "«www.snapp.ir»، خیابن", term: "www.snapp.ir خیابان"},
+		{phrase: "https://snapp.ir/ride خیابن", term: "https://snapp.ir/ride خیابان"},
+		{phrase: "کد SKU-12", term: "کد SKU-12"},
+	}
+	for _, test := range tests {
+		result := symSpell.LookupCompound(test.phrase, 2)
+		if result.Term != test.term {
+			t.Errorf("For phrase '%s', expected '%s', got '%s'", test.phrase, test.term, result.Term)
+		}
+	}
+
+	results, _ := symSpell.Lookup("support@snapp.ir", verbositypkg.Top, 2)
+	if len(results) != 1 || results[0].Term != "support@snapp.ir" || !results[0].Ignored {
+		t.Errorf("Expected the email to be passed through, got %v", results)
+	}
+
+	withoutRules, _ := NewSymSpell()
+	withoutRules.createDictionaryEntry("پلاک", 30)
+	withoutRules.createDictionaryEntry("2", 1000)
+	if result := withoutRules.LookupCompound("پلاک 12", 2); result.Term != "پلاک 2" {
+		t.Errorf("Expected numbers to be corrected without ignore rules, got '%s'", result.Term)
+	}
+}
+
 func Test_separateNumbers(t *testing.T) {
 	type args struct {
 		inputs string
@@ -116,3 +234,139 @@ func Test_separateNumbers(t *testing.T) {
 		})
 	}
 }
+
+func TestLookupCompoundAlignment(t *testing.T) {
+	symSpell, _ := NewSymSpell(options.WithMaxDictionaryEditDistance(2))
+	symSpell.createDictionaryEntry("میدان", 300)
+	symSpell.createDictionaryEntry("خیابان", 200)
+	symSpell.createDictionaryEntry("آزادی", 90)
+	symSpell.createDictionaryEntry("نجف‌آباد", 100)
+	symSpell.createDictionaryEntry("نجف", 50)
+	symSpell.createDictionaryEntry("آباد", 40)
+	symSpell.ExactTransform["م"] = "میدان"
+
+	result := symSpell.LookupCompound("م خیابن نجف آباد خیابانآزادی آزادی", 2)
+	if result.Term != "میدان خیابان نجف‌آباد خیابان آزادی آزادی" {
+		t.Fatalf("Unexpected term '%s'", result.Term)
+	}
+	expected := []struct {
+		operation  items.Operation
+		start, end int
+		output     []string
+		distance   int
+	}{
+		{operation: items.ExactTransform, start: 0, end: 1, output: []string{"میدان"}},
+		{operation: items.Replace, start: 1, end: 2, output: []string{"خیابان"}, distance: 1},
+		{operation: items.Merge, start: 2, end: 4, output: []string{"نجف‌آباد"}},
+		{operation: items.Split, start: 4, end: 5, output: []string{"خیابان", "آزادی"}, distance: 1},
+		{operation: items.Keep, start: 5, end: 6, output: []string{"آزادی"}},
+	}
+	if len(result.Alignment) != len(expected) {
+		t.Fatalf("Expected %d aligned spans, got %v", len(expected), result.Alignment)
+	}
+	for i, want := range expected {
+		got := result.Alignment[i]
+		if got.Operation != want.operation || got.Start != want.start || got.End != want.end ||
+			!reflect.DeepEqual(got.Output, want.output) || got.Distance != want.distance {
+			t.Errorf("For span %d, expected %s [%d, %d) %v distance %d, got %s [%d, %d) %v distance %d", i,
+				want.operation, want.start, want.end, want.output, want.distance,
+				got.Operation, got.Start, got.End, got.Output, got.Distance)
+		}
+	}
+}
+
+func TestLookupCompoundTopK(t *testing.T) {
+	symSpell, _ := NewSymSpell(options.WithMaxDictionaryEditDistance(2))
+	symSpell.createDictionaryEntry("steam", 40)
+	symSpell.createDictionaryEntry("stream", 30)
+	symSpell.createDictionaryEntry("team", 60)
+	symSpell.createDictionaryEntry("engine", 50)
+	symSpell.Bigrams["steam engine"] = 20
+
+	results := symSpell.LookupCompoundTopK("stem engine", 3, verbositypkg.All, 2)
+	expected := []string{"steam engine", "team engine", "stream engine"}
+	if len(results) != len(expected) {
+		t.Fatalf("Expected %d alternatives, got %v", len(expected), results)
+	}
+	for i, term := range expected {
+		if results[i].Term != term {
+			t.Errorf("For alternative %d, expected '%s', got '%s'", i, term, results[i].Term)
+		}
+		if i > 0 && results[i].Score > results[i-1].Score {
+			t.Errorf("Expected alternatives ranked by score, got %v", results)
+		}
+	}
+	if results[1].Alignment[0].Operation != items.Replace || results[1].Alignment[0].Distance != 2 {
+		t.Errorf("Expected the alignment of the alternative, got %v", results[1].Alignment)
+	}
+
+	if results := symSpell.LookupCompoundTopK("stem engine", 2, verbositypkg.All, 2); len(results) != 2 {
+		t.Errorf("Expected 2 alternatives, got %v", results)
+	}
+	// The closest suggestions only include those at the smallest distance
+	if results := symSpell.LookupCompoundTopK("stem engine", 3, verbositypkg.Closest, 2); len(results) != 1 ||
+		results[0].Term != symSpell.LookupCompound("stem engine", 2).Term {
+		t.Errorf("Expected the LookupCompound term alone, got %v", results)
+	}
+	if results := symSpell.LookupCompoundTopK("stem engine", 0, verbositypkg.All, 2); results != nil {
+		t.Errorf("Expected no alternatives for k = 0, got %v", results)
+	}
+}
+
+func TestLookupCompoundWithRealWordErrors(t *testing.T) {
+	newSymSpell := func(opts ...options.Options) *SymSpell {
+		symSpell, err := NewSymSpell(opts...)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		symSpell.createDictionaryEntry("میدان", 300)
+		symSpell.createDictionaryEntry("خیابان", 200)
+		symSpell.createDictionaryEntry("آزادی", 50)
+		symSpell.createDictionaryEntry("آزاد", 40)
+		symSpell.createDictionaryEntry("شمالی", 30)
+		symSpell.Bigrams["میدان آزادی"] = 20
+		symSpell.Bigrams["آزادی شمالی"] = 4
+		return symSpell
+	}
+
+	symSpell := newSymSpell(options.WithRealWordErrors(10))
+	tests := []struct {
+		phrase string
+		term   string
+	}{
+		{phrase: "میدان آزاد", term: "میدان آزادی"},
+		{phrase: "آزاد شمالی", term: "آزادی شمالی"},
+		{phrase: "میدان آزادی", term: "میدان آزادی"},
+		// Without a bigram of the replacement, a dictionary word is kept
+		{phrase: "خیابان آزاد", term: "خیابان آزاد"},
+		{phrase: "آزاد", term: "آزاد"},
+	}
+	for _, test := range tests {
+		result := symSpell.LookupCompound(test.phrase, 2)
+		if result.Term != test.term {
+			t.Errorf("For phrase '%s', expected '%s', got '%s'", test.phrase, test.term, result.Term)
+		}
+	}
+	if alignment := symSpell.LookupCompound("میدان آزاد", 2).Alignment; alignment[1].Operation != items.Replace ||
+		alignment[1].Distance != 1 {
+		t.Errorf("Expected the real word to be aligned as replaced, got %v", alignment)
+	}
+
+	if result := newSymSpell().LookupCompound("میدان آزاد", 2); result.Term != "میدان آزاد" {
+		t.Errorf("Expected dictionary words to be kept without real-word errors, got '%s'", result.Term)
+	}
+
+	// The confidence ratio is compared to the context probability of the word itself
+	for _, ratio := range []float64{2, 10} {
+		symSpell := newSymSpell(options.WithRealWordErrors(ratio))
+		symSpell.Bigrams["میدان آزاد"] = 5
+		expected := map[float64]string{2: "میدان آزادی", 10: "میدان آزاد"}[ratio]
+		if result := symSpell.LookupCompound("میدان آزاد", 2); result.Term != expected {
+			t.Errorf("For ratio %v, expected '%s', got '%s'", ratio, expected, result.Term)
+		}
+	}
+
+	if _, err := NewSymSpell(options.WithRealWordErrors(0.5)); err == nil {
+		t.Errorf("Expected an error for a ratio less than 1")
+	}
+}
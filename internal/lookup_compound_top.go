@@ -0,0 +1,164 @@
+package internal
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/snapp-incubator/go-symspell/pkg/items"
+	verbositypkg "github.com/snapp-incubator/go-symspell/pkg/verbosity"
+)
+
+const (
+	// maxSpanAlternatives limits the suggestions of a term that are combined into alternatives.
+	maxSpanAlternatives = 8
+	// bigramBackoff scales the probability of a word that does not form a known bigram with the previous word.
+	bigramBackoff = 0.4
+	// editLogPenalty is subtracted from the log10 score for every edit, an edit is ten times less likely than none.
+	editLogPenalty = 1.0
+)
+
+// compoundHypothesis is a partial alternative of LookupCompoundTopK.
+type compoundHypothesis struct {
+	alignment []items.Alignment
+	score     float64
+	last      string
+	term      string
+}
+
+// LookupCompoundTopK returns up to k corrections of a phrase, ranked by their joint score. The phrase is split,
+// merged and corrected like LookupCompound, then the suggestions of every term found with the verbosity are
+// combined, scoring each alternative by the bigram probabilities of its terms less a penalty per edit.
+func (s *SymSpell) LookupCompoundTopK(
+	phrase string,
+	k int,
+	verbosity verbositypkg.Verbosity,
+	maxEditDistance int,
+) []items.SuggestItem {
+	if k <= 0 {
+		return nil
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	terms, ignored := s.parseTerms(phrase)
+	// lookupCompound transliterates the terms in place, the alternatives are those of the terms it corrected
+	best := s.lookupCompound(phrase, terms, ignored, maxEditDistance)
+
+	hypotheses := []compoundHypothesis{{}}
+	for _, span := range best.Alignment {
+		byLast := make(map[string][]compoundHypothesis)
+		term := s.replaceExactMatch(terms[span.Start])
+		for _, alternative := range s.spanAlternatives(span, term, verbosity, maxEditDistance) {
+			for _, hypothesis := range hypotheses {
+				extended := s.extendHypothesis(hypothesis, alternative)
+				byLast[extended.last] = append(byLast[extended.last], extended)
+			}
+		}
+		// The score of the next terms depends only on the last term, the k best hypotheses of every last term
+		// contain the k best alternatives
+		hypotheses = nil
+		for _, group := range byLast {
+			sortHypotheses(group)
+			hypotheses = append(hypotheses, group[:min(k, len(group))]...)
+		}
+	}
+	sortHypotheses(hypotheses)
+
+	suggestions := make([]items.SuggestItem, 0, k)
+	for _, hypothesis := range hypotheses[:min(k, len(hypotheses))] {
+		parts := make([]items.SuggestItem, len(hypothesis.alignment))
+		for i, span := range hypothesis.alignment {
+			parts[i] = items.SuggestItem{Term: strings.Join(span.Output, " "), Count: span.Count}
+		}
+		suggestion := s.finalizeAnswer(phrase, parts)
+		suggestion.Score = hypothesis.score
+		suggestion.Alignment = hypothesis.alignment
+		suggestion.Transliterated = best.Transliterated
+		suggestions = append(suggestions, *suggestion)
+	}
+	return suggestions
+}
+
+// spanAlternatives returns the span as LookupCompound corrected it, followed by the other suggestions of the term
+// it looked up, after transliteration and exact transforms. Merged, split, transformed and ignored spans have no
+// alternatives.
+func (s *SymSpell) spanAlternatives(
+	span items.Alignment,
+	term string,
+	verbosity verbositypkg.Verbosity,
+	maxEditDistance int,
+) []items.Alignment {
+	alternatives := []items.Alignment{span}
+	if span.Operation != items.Keep && span.Operation != items.Replace || s.ignored(span.Input[0]) {
+		return alternatives
+	}
+	suggestions, _ := s.lookup(term, verbosity, maxEditDistance)
+	for _, suggestion := range suggestions[:min(maxSpanAlternatives, len(suggestions))] {
+		if suggestion.Term == span.Output[0] {
+			continue
+		}
+		alternative := span
+		alternative.Output = []string{suggestion.Term}
+		alternative.Distance = suggestion.Distance
+		alternative.Count = suggestion.Count
+		alternative.Operation = items.Replace
+		if suggestion.Term == span.Input[0] {
+			alternative.Operation = items.Keep
+		}
+		alternatives = append(alternatives, alternative)
+	}
+	return alternatives
+}
+
+// extendHypothesis appends a span to a hypothesis and adds its score.
+func (s *SymSpell) extendHypothesis(hypothesis compoundHypothesis, span items.Alignment) compoundHypothesis {
+	extended := compoundHypothesis{
+		alignment: append(slices.Clip(hypothesis.alignment), span),
+		score:     hypothesis.score - editLogPenalty*float64(span.Distance),
+		last:      hypothesis.last,
+		term:      strings.TrimSpace(hypothesis.term + " " + strings.Join(span.Output, " ")),
+	}
+	if s.ignored(span.Input[0]) {
+		// Ignored tokens are not words, the next word is scored without a previous word
+		extended.last = ""
+		return extended
+	}
+	for _, term := range span.Output {
+		count, found := s.Words[term]
+		if !found && len(span.Output) == 1 {
+			count = span.Count
+		}
+		extended.score += s.termLogProbability(extended.last, term, count)
+		extended.last = term
+	}
+	return extended
+}
+
+// termLogProbability is the log10 probability of a term following the previous term, it backs off to the
+// probability of the term alone when the two do not form a known bigram. Unknown terms are scored like in
+// WordSegmentation.
+func (s *SymSpell) termLogProbability(previous, term string, count int) float64 {
+	if count <= 0 {
+		return math.Log10(10.0 / (s.N * math.Pow(10.0, float64(len([]rune(term))))))
+	}
+	if previous == "" {
+		return math.Log10(float64(count) / s.N)
+	}
+	if bigramCount, found := s.Bigrams[previous+" "+term]; found {
+		if previousCount, found := s.Words[previous]; found && previousCount >= bigramCount {
+			return math.Log10(float64(bigramCount) / float64(previousCount))
+		}
+	}
+	return math.Log10(bigramBackoff * float64(count) / s.N)
+}
+
+// sortHypotheses orders hypotheses by descending score, then by term so that the order is deterministic.
+func sortHypotheses(hypotheses []compoundHypothesis) {
+	sort.Slice(hypotheses, func(i, j int) bool {
+		if hypotheses[i].score != hypotheses[j].score {
+			return hypotheses[i].score > hypotheses[j].score
+		}
+		return hypotheses[i].term < hypotheses[j].term
+	})
+}
@@ -0,0 +1,50 @@
+package internal
+
+import (
+	"sort"
+
+	"github.com/snapp-incubator/go-symspell/pkg/items"
+	verbositypkg "github.com/snapp-incubator/go-symspell/pkg/verbosity"
+)
+
+// LookupInContext looks a word up like Lookup and ranks the suggestions at the same distance by the bigrams they
+// form with the words on their left and right, an empty word has no context. The context score of a suggestion,
+// the log10 probability of the suggestion after the left word and of the right word after the suggestion, is
+// reported in Score.
+func (s *SymSpell) LookupInContext(
+	left, word, right string,
+	verbosity verbositypkg.Verbosity,
+	maxEditDistance int,
+) ([]items.SuggestItem, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.ignored(word) {
+		return []items.SuggestItem{{Term: word, Distance: 0, Count: s.Words[word], Ignored: true}}, nil
+	}
+	// The context can reorder the closest suggestions, so all of them are ranked before trimming to the top one
+	suggestions, err := s.lookup(s.normalize(word), max(verbosity, verbositypkg.Closest), maxEditDistance)
+	if err != nil {
+		return nil, err
+	}
+	left, right = s.normalize(left), s.normalize(right)
+	for i := range suggestions {
+		suggestions[i].Score = s.contextLogProbability(left, suggestions[i], right)
+	}
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if s.rankDistance(a) != s.rankDistance(b) {
+			return s.rankDistance(a) < s.rankDistance(b)
+		}
+		return a.Score > b.Score
+	})
+	return s.trimToVerbosity(suggestions, verbosity), nil
+}
+
+// contextLogProbability is the log10 probability of a suggestion between its left and right words.
+func (s *SymSpell) contextLogProbability(left string, suggestion items.SuggestItem, right string) float64 {
+	score := s.termLogProbability(left, suggestion.Term, suggestion.Count)
+	if right != "" {
+		score += s.termLogProbability(suggestion.Term, right, s.Words[right])
+	}
+	return score
+}
@@ -1,8 +1,13 @@
 package internal
 
 import (
+	"bytes"
+	"strings"
 	"testing"
 
+	"github.com/snapp-incubator/go-symspell/pkg/editdistance"
+	"github.com/snapp-incubator/go-symspell/pkg/morphology"
+	"github.com/snapp-incubator/go-symspell/pkg/normalizer"
 	"github.com/snapp-incubator/go-symspell/pkg/options"
 	verbositypkg "github.com/snapp-incubator/go-symspell/pkg/verbosity"
 )
@@ -301,3 +306,494 @@ func TestFarsi(t *testing.T) {
 		t.Errorf("Expected term 'steama', got '%s'", results[0].Term)
 	}
 }
+
+type countingEditDistance struct {
+	calls int
+	editdistance.IEditDistance
+}
+
+func (c *countingEditDistance) Distance(a, b string) int {
+	c.calls++
+	return c.IEditDistance.Distance(a, b)
+}
+
+func TestLookupWithEditDistance(t *testing.T) {
+	symSpell, err := NewSymSpell(options.WithEditDistance(editdistance.NewEditDistance(editdistance.Levenshtein)))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	symSpell.createDictionaryEntry("steam", 1)
+	symSpell.createDictionaryEntry("setam", 2)
+
+	// Levenshtein counts a transposition as two edits
+	results, _ := symSpell.Lookup("setam", verbositypkg.All, 2)
+	if len(results) != 2 || results[1].Term != "steam" || results[1].Distance != 2 {
+		t.Errorf("Expected 'steam' at distance 2, got %v", results)
+	}
+
+	custom := &countingEditDistance{IEditDistance: editdistance.NewEditDistance(editdistance.DamerauLevenshtein)}
+	symSpell, err = NewSymSpell(options.WithEditDistance(custom))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	symSpell.createDictionaryEntry("steam", 1)
+	symSpell.createDictionaryEntry("setam", 2)
+	results, _ = symSpell.Lookup("setam", verbositypkg.All, 2)
+	if len(results) != 2 || results[1].Distance != 1 || custom.calls == 0 {
+		t.Errorf("Expected custom edit distance to be used, got %v", results)
+	}
+
+	// Jaro-Winkler distances are rounded up, suggestions at the same distance are ranked by similarity
+	symSpell, err = NewSymSpell(options.WithEditDistance(editdistance.NewEditDistance(editdistance.JaroWinkler)))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	symSpell.createDictionaryEntry("martha", 1)
+	symSpell.createDictionaryEntry("marhtb", 100)
+	results, _ = symSpell.Lookup("marhta", verbositypkg.All, 2)
+	if len(results) != 2 || results[0].Term != "martha" || results[0].Distance != 1 || results[1].Distance != 1 ||
+		results[0].WeightedDistance >= results[1].WeightedDistance {
+		t.Errorf("Expected 'martha' ranked first at distance 1, got %v", results)
+	}
+
+	if _, err = NewSymSpell(options.WithEditDistance(nil)); err == nil {
+		t.Errorf("Expected error for nil edit distance")
+	}
+}
+
+func TestLookupWithWeightedEditDistance(t *testing.T) {
+	symSpell, err := NewSymSpell(options.WithWeightedEditDistance(editdistance.NewPersianWeighted()))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	symSpell.createDictionaryEntry("دره", 100)
+	symSpell.createDictionaryEntry("ذره", 5)
+	symSpell.createDictionaryEntry("ضرب", 50)
+
+	results, _ := symSpell.Lookup("ضره", verbositypkg.Top, 2)
+	if len(results) != 1 || results[0].Term != "ذره" {
+		t.Fatalf("Expected 'ذره', got %v", results)
+	}
+	if results[0].Distance != 1 || results[0].WeightedDistance != editdistance.PersianConfusionCost {
+		t.Errorf("Expected distance 1 and weighted distance %v, got %v", editdistance.PersianConfusionCost, results[0])
+	}
+
+	results, _ = symSpell.Lookup("ضره", verbositypkg.Closest, 2)
+	if len(results) != 1 {
+		t.Errorf("Expected a single closest suggestion, got %v", results)
+	}
+	results, _ = symSpell.Lookup("ضره", verbositypkg.All, 2)
+	if len(results) != 3 || results[1].Term != "دره" || results[2].Term != "ضرب" {
+		t.Errorf("Expected suggestions ordered by weighted distance then count, got %v", results)
+	}
+	results, _ = symSpell.Lookup("دره", verbositypkg.Top, 2)
+	if len(results) != 1 || results[0].Term != "دره" || results[0].WeightedDistance != 0 {
+		t.Errorf("Expected exact match, got %v", results)
+	}
+}
+
+func TestLookupWithKeyboardLayouts(t *testing.T) {
+	symSpell, err := NewSymSpell(options.WithKeyboardLayouts(editdistance.QWERTY, editdistance.PersianISIRI9147))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	symSpell.createDictionaryEntry("cut", 100)
+	symSpell.createDictionaryEntry("cat", 10)
+	symSpell.createDictionaryEntry("cast", 1000)
+	symSpell.createDictionaryEntry("میدار", 100)
+	symSpell.createDictionaryEntry("میدان", 10)
+
+	tests := []struct {
+		typo       string
+		verbosity  verbositypkg.Verbosity
+		correction string
+		numResults int
+	}{
+		{typo: "cst", verbosity: verbositypkg.Top, correction: "cat", numResults: 1},
+		{typo: "cst", verbosity: verbositypkg.Closest, correction: "cat", numResults: 3},
+		{typo: "میدام", verbosity: verbositypkg.Top, correction: "میدان", numResults: 1},
+		{typo: "میدام", verbosity: verbositypkg.All, correction: "میدان", numResults: 2},
+	}
+	for _, test := range tests {
+		results, err := symSpell.Lookup(test.typo, test.verbosity, 2)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(results) != test.numResults || results[0].Term != test.correction {
+			t.Errorf("For typo '%s', expected %d results starting with '%s', got %v",
+				test.typo, test.numResults, test.correction, results)
+		}
+	}
+}
+
+func TestLookupWithLayoutSwap(t *testing.T) {
+	symSpell, err := NewSymSpell(options.WithLayoutSwap(), options.WithSplitWordBySpace())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	symSpell.createDictionaryEntry("خیابان", 100)
+	symSpell.createDictionaryEntry("آزادی", 50)
+	symSpell.createDictionaryEntry("street", 10)
+
+	tests := []struct {
+		typo       string
+		correction string
+		swapped    bool
+	}{
+		{typo: "odhfhk", correction: "خیابان", swapped: true},
+		{typo: "odhfk", correction: "خیابان", swapped: true},
+		{typo: "hchnd", correction: "آزادی", swapped: true},
+		{typo: "سفقثثف", correction: "street", swapped: true},
+		{typo: "streat", correction: "street", swapped: false},
+	}
+	for _, test := range tests {
+		results, err := symSpell.Lookup(test.typo, verbositypkg.Top, 2)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(results) == 0 {
+			t.Errorf("Expected results for typo '%s', got none", test.typo)
+			continue
+		}
+		if results[0].LayoutSwapped != test.swapped || results[0].Term != test.correction {
+			t.Errorf("For typo '%s', expected '%s' swapped %v, got %v", test.typo, test.correction, test.swapped, results[0])
+		}
+	}
+
+	result := symSpell.LookupCompound("odhfhk آزادی", 2)
+	if result.Term != "خیابان آزادی" || !result.LayoutSwapped {
+		t.Errorf("Expected swapped 'خیابان آزادی', got %v", result)
+	}
+}
+
+func TestLookupWithNormalizer(t *testing.T) {
+	symSpell, err := NewSymSpell(options.WithNormalizer(normalizer.Chain(normalizer.NFKC, normalizer.Persian())))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	_, err = symSpell.LoadDictionaryStream(strings.NewReader("كتاب 100\nميدان 50\n"), 0, 1, " ")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	_, err = symSpell.LoadExactDictionaryStream(strings.NewReader("ك.ت کتاب\n"), " ")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, found := symSpell.Words["کتاب"]; !found {
+		t.Errorf("Expected the normalized term to be stored, got %v", symSpell.Words)
+	}
+
+	tests := []struct {
+		query      string
+		correction string
+		distance   int
+	}{
+		{query: "کتاب", correction: "کتاب", distance: 0},
+		{query: "كِتَاب", correction: "کتاب", distance: 0},
+		{query: "کتـــاب", correction: "کتاب", distance: 0},
+		{query: "مىدان", correction: "میدان", distance: 0},
+		{query: "ميدن", correction: "میدان", distance: 1},
+	}
+	for _, test := range tests {
+		results, err := symSpell.Lookup(test.query, verbositypkg.Top, 2)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(results) != 1 || results[0].Term != test.correction || results[0].Distance != test.distance {
+			t.Errorf("For query '%s', expected '%s' at distance %d, got %v", test.query, test.correction, test.distance, results)
+		}
+	}
+
+	if !symSpell.IncrementCount("كتاب", 1) || symSpell.Words["کتاب"] != 101 {
+		t.Errorf("Expected the count of the normalized term to be incremented, got %v", symSpell.Words)
+	}
+	if _, found := symSpell.ExactTransform["ک.ت"]; !found {
+		t.Errorf("Expected the normalized exact transform, got %v", symSpell.ExactTransform)
+	}
+}
+
+func TestLookupWithAffixes(t *testing.T) {
+	symSpell, err := NewSymSpell(options.WithAffixes(morphology.Persian))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	symSpell.createDictionaryEntry("کتاب", 100)
+	symSpell.createDictionaryEntry("کباب", 500)
+	symSpell.createDictionaryEntry("خیابان", 200)
+	symSpell.createDictionaryEntry("روم", 40)
+	symSpell.createDictionaryEntry("آزادی", 50)
+
+	tests := []struct {
+		query    string
+		term     string
+		stem     string
+		prefix   string
+		suffix   string
+		distance int
+	}{
+		{query: "کتاب\u200cها", term: "کتاب\u200cها", stem: "کتاب", suffix: "ها", distance: 0},
+		{query: "کتابهای", term: "کتابهای", stem: "کتاب", suffix: "های", distance: 0},
+		{query: "خیابن\u200cها", term: "خیابان\u200cها", stem: "خیابان", suffix: "ها", distance: 1},
+		{query: "می\u200cروم", term: "می\u200cروم", stem: "روم", prefix: "می", distance: 0},
+		{query: "آزادی", term: "آزادی", distance: 0},
+		{query: "کتابی", term: "کتابی", stem: "کتاب", suffix: "ی", distance: 0},
+	}
+	for _, test := range tests {
+		results, err := symSpell.Lookup(test.query, verbositypkg.Top, 2)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(results) != 1 {
+			t.Errorf("For query '%s', expected a single result, got %v", test.query, results)
+			continue
+		}
+		result := results[0]
+		if result.Term != test.term || result.Stem != test.stem || result.Prefix != test.prefix ||
+			result.Suffix != test.suffix || result.Distance != test.distance {
+			t.Errorf("For query '%s', expected '%s' with stem '%s', prefix '%s', suffix '%s' at distance %d, got %v",
+				test.query, test.term, test.stem, test.prefix, test.suffix, test.distance, result)
+		}
+	}
+
+	results, _ := symSpell.Lookup("کتابرا", verbositypkg.Closest, 2)
+	if len(results) != 1 || results[0].Term != "کتابرا" || results[0].Count != 100 {
+		t.Errorf("Expected the affixed stem to rank before other words, got %v", results)
+	}
+}
+
+func TestLookupWithSkeletonIndex(t *testing.T) {
+	symSpell, err := NewSymSpell(options.WithSkeletonIndex(0.25))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	symSpell.createDictionaryEntry("تبریز", 100)
+	symSpell.createDictionaryEntry("نیریز", 50)
+	symSpell.createDictionaryEntry("بار", 100)
+	symSpell.createDictionaryEntry("کار", 1000)
+
+	tests := []struct {
+		query      string
+		verbosity  verbositypkg.Verbosity
+		term       string
+		numResults int
+		distance   int
+		cost       float64
+	}{
+		// All dots dropped by OCR, beyond the max edit distance
+		{query: "ٮٮرٮر", verbosity: verbositypkg.Closest, term: "تبریز", numResults: 2, distance: 4, cost: 1},
+		{query: "تیریز", verbosity: verbositypkg.Closest, term: "تبریز", numResults: 2, distance: 1, cost: 0.25},
+		{query: "ثبریز", verbosity: verbositypkg.Top, term: "تبریز", numResults: 1, distance: 1, cost: 0.25},
+		// A misplaced dot ranks before a more frequent word at the same edit distance
+		{query: "نار", verbosity: verbositypkg.Top, term: "بار", numResults: 1, distance: 1, cost: 0.25},
+	}
+	for _, test := range tests {
+		results, err := symSpell.Lookup(test.query, test.verbosity, 2)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(results) != test.numResults {
+			t.Errorf("For query '%s', expected %d results, got %v", test.query, test.numResults, results)
+			continue
+		}
+		result := results[0]
+		if result.Term != test.term || !result.SkeletonMatched || result.Distance != test.distance ||
+			result.WeightedDistance != test.cost {
+			t.Errorf("For query '%s', expected '%s' at distance %d and cost %v, got %v",
+				test.query, test.term, test.distance, test.cost, result)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := symSpell.SaveSnapshot(&buf); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	loaded, _ := NewSymSpell(options.WithSkeletonIndex(0.25))
+	if err := loaded.LoadSnapshot(&buf); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	results, _ := loaded.Lookup("ٮٮرٮر", verbositypkg.Top, 2)
+	if len(results) != 1 || results[0].Term != "تبریز" {
+		t.Errorf("Expected the skeleton index to be rebuilt from a snapshot, got %v", results)
+	}
+
+	symSpell.DeleteDictionaryEntry("بار")
+	results, _ = symSpell.Lookup("نار", verbositypkg.Top, 2)
+	if len(results) != 1 || results[0].Term != "کار" || results[0].SkeletonMatched {
+		t.Errorf("Expected a deleted word to be removed from the skeleton index, got %v", results)
+	}
+
+	if _, err = NewSymSpell(options.WithSkeletonIndex(-1)); err == nil {
+		t.Errorf("Expected error for negative skeleton cost")
+	}
+}
+
+func TestLookupWithPhoneticIndex(t *testing.T) {
+	symSpell, err := NewSymSpell(options.WithPhoneticIndex())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	symSpell.createDictionaryEntry("خیابان", 100)
+	symSpell.createDictionaryEntry("تهران", 80)
+	symSpell.createDictionaryEntry("ثواب", 10)
+	symSpell.createDictionaryEntry("صواب", 5)
+
+	tests := []struct {
+		query           string
+		verbosity       verbositypkg.Verbosity
+		maxEditDistance int
+		terms           []string
+		phoneticMatched bool
+	}{
+		{query: "khiaban", verbosity: verbositypkg.Top, maxEditDistance: 2, terms: []string{"خیابان"}, phoneticMatched: true},
+		{query: "Tehran", verbosity: verbositypkg.Closest, maxEditDistance: 2, terms: []string{"تهران"}, phoneticMatched: true},
+		{query: "سواب", verbosity: verbositypkg.Closest, maxEditDistance: 0, terms: []string{"ثواب", "صواب"}, phoneticMatched: true},
+		{query: "خیابن", verbosity: verbositypkg.Top, maxEditDistance: 2, terms: []string{"خیابان"}, phoneticMatched: false},
+		{query: "kh", verbosity: verbositypkg.Top, maxEditDistance: 2, terms: nil},
+	}
+	for _, test := range tests {
+		results, err := symSpell.Lookup(test.query, test.verbosity, test.maxEditDistance)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(results) != len(test.terms) {
+			t.Errorf("For query '%s', expected %v, got %v", test.query, test.terms, results)
+			continue
+		}
+		for i, result := range results {
+			if result.Term != test.terms[i] || result.PhoneticMatched != test.phoneticMatched {
+				t.Errorf("For query '%s', expected %v phonetic matched %v, got %v",
+					test.query, test.terms, test.phoneticMatched, results)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := symSpell.SaveSnapshot(&buf); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	loaded, _ := NewSymSpell(options.WithPhoneticIndex())
+	if err := loaded.LoadSnapshot(&buf); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	results, _ := loaded.Lookup("khiaban", verbositypkg.Top, 2)
+	if len(results) != 1 || results[0].Term != "خیابان" {
+		t.Errorf("Expected the phonetic index to be rebuilt from a snapshot, got %v", results)
+	}
+
+	symSpell.DeleteDictionaryEntry("تهران")
+	if results, _ = symSpell.Lookup("tehran", verbositypkg.Top, 2); len(results) != 0 {
+		t.Errorf("Expected a deleted word to be removed from the phonetic index, got %v", results)
+	}
+}
+
+func TestLookupWithRepeatCollapse(t *testing.T) {
+	symSpell, err := NewSymSpell(options.WithRepeatCollapse())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	symSpell.createDictionaryEntry("سلام", 100)
+	symSpell.createDictionaryEntry("خیابان", 200)
+	symSpell.createDictionaryEntry("الله", 50)
+	symSpell.createDictionaryEntry("cool", 20)
+	symSpell.createDictionaryEntry("col", 10)
+
+	tests := []struct {
+		query     string
+		verbosity verbositypkg.Verbosity
+		terms     []string
+		distance  int
+		collapsed bool
+	}{
+		{query: "سلاااام", verbosity: verbositypkg.Top, terms: []string{"سلام"}, distance: 3, collapsed: true},
+		{query: "خیااااابان", verbosity: verbositypkg.Top, terms: []string{"خیابان"}, distance: 4, collapsed: true},
+		{query: "سلااااممم", verbosity: verbositypkg.Top, terms: []string{"سلام"}, distance: 5, collapsed: true},
+		{query: "اللللله", verbosity: verbositypkg.Top, terms: []string{"الله"}, distance: 3, collapsed: true},
+		{query: "cooooool", verbosity: verbositypkg.All, terms: []string{"cool", "col"}, distance: 4, collapsed: true},
+		{query: "الله", verbosity: verbositypkg.Top, terms: []string{"الله"}, distance: 0, collapsed: false},
+		{query: "سلم", verbosity: verbositypkg.Top, terms: []string{"سلام"}, distance: 1, collapsed: false},
+	}
+	for _, test := range tests {
+		results, err := symSpell.Lookup(test.query, test.verbosity, 2)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(results) != len(test.terms) {
+			t.Errorf("For query '%s', expected %v, got %v", test.query, test.terms, results)
+			continue
+		}
+		for i, result := range results {
+			if result.Term != test.terms[i] || result.Collapsed != test.collapsed {
+				t.Errorf("For query '%s', expected %v collapsed %v, got %v", test.query, test.terms, test.collapsed, results)
+			}
+		}
+		if results[0].Distance != test.distance {
+			t.Errorf("For query '%s', expected distance %d, got %d", test.query, test.distance, results[0].Distance)
+		}
+	}
+
+	// A lookup without edits only finds exact matches
+	if results, _ := symSpell.Lookup("سلاااام", verbositypkg.All, 0); len(results) != 0 {
+		t.Errorf("Expected no result for a max edit distance of 0, got %v", results)
+	}
+
+	// With verbosity All, the words found by edit distance are kept along with the collapsed words
+	symSpell.createDictionaryEntry("coolly", 5)
+	results, _ := symSpell.Lookup("cooll", verbositypkg.All, 2)
+	if len(results) != 3 || results[0].Term != "cool" || results[1].Term != "coolly" || results[2].Term != "col" {
+		t.Errorf("Expected the collapsed and edit distance suggestions, got %v", results)
+	}
+	if results, _ := symSpell.Lookup("cooll", verbositypkg.Top, 2); len(results) != 1 || !results[0].Collapsed {
+		t.Errorf("Expected the collapsed word alone, got %v", results)
+	}
+
+	withoutCollapse, _ := NewSymSpell()
+	withoutCollapse.createDictionaryEntry("سلام", 100)
+	if results, _ := withoutCollapse.Lookup("سلاااام", verbositypkg.Top, 2); len(results) != 0 {
+		t.Errorf("Expected no result without repeat collapse, got %v", results)
+	}
+}
+
+func TestLookupInContext(t *testing.T) {
+	symSpell, _ := NewSymSpell()
+	symSpell.createDictionaryEntry("میدان", 300)
+	symSpell.createDictionaryEntry("خیابان", 200)
+	symSpell.createDictionaryEntry("آزادی", 50)
+	symSpell.createDictionaryEntry("ازادی", 100)
+	symSpell.createDictionaryEntry("شمالی", 30)
+	symSpell.createDictionaryEntry("پلاک", 40)
+	symSpell.Bigrams["میدان آزادی"] = 20
+	symSpell.Bigrams["آزادی شمالی"] = 5
+
+	tests := []struct {
+		left, word, right string
+		verbosity         verbositypkg.Verbosity
+		terms             []string
+	}{
+		// An exact match is never replaced
+		{left: "میدان", word: "ازادی", verbosity: verbositypkg.Top, terms: []string{"ازادی"}},
+		{left: "میدان", word: "زادی", verbosity: verbositypkg.Top, terms: []string{"آزادی"}},
+		{left: "میدان", word: "زادی", verbosity: verbositypkg.Closest, terms: []string{"آزادی", "ازادی"}},
+		{word: "زادی", verbosity: verbositypkg.Top, terms: []string{"ازادی"}},
+		{left: "خیابان", word: "زادی", right: "پلاک", verbosity: verbositypkg.Closest, terms: []string{"ازادی", "آزادی"}},
+		{word: "زادی", right: "شمالی", verbosity: verbositypkg.Top, terms: []string{"آزادی"}},
+	}
+	for _, test := range tests {
+		results, err := symSpell.LookupInContext(test.left, test.word, test.right, test.verbosity, 2)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(results) != len(test.terms) {
+			t.Errorf("For query '%s %s %s', expected %v, got %v", test.left, test.word, test.right, test.terms, results)
+			continue
+		}
+		for i, result := range results {
+			if result.Term != test.terms[i] {
+				t.Errorf("For query '%s %s %s', expected %v, got %v", test.left, test.word, test.right, test.terms, results)
+			}
+		}
+	}
+
+	if _, err := symSpell.LookupInContext("", "آزادی", "", verbositypkg.Top, 3); err == nil {
+		t.Errorf("Expected an error for a distance larger than the max dictionary edit distance")
+	}
+}
@@ -0,0 +1,62 @@
+package internal
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/snapp-incubator/go-symspell/pkg/items"
+	"github.com/snapp-incubator/go-symspell/pkg/phonetic"
+	verbositypkg "github.com/snapp-incubator/go-symspell/pkg/verbosity"
+)
+
+// minPhoneticKeyLength is the shortest key looked up, shorter keys are shared by too many words.
+const minPhoneticKeyLength = 2
+
+// addPhonetic indexes a word by its phonetic key when the phonetic index is enabled.
+func (s *SymSpell) addPhonetic(word string) {
+	if !s.phoneticIndex {
+		return
+	}
+	if key := phonetic.Key(word); key != "" {
+		s.Phonetics[key] = append(s.Phonetics[key], word)
+	}
+}
+
+// lookupPhonetic falls back to the words sounding like the phrase when no suggestion was found within the max edit
+// distance, with verbosity All they are listed after the suggestions.
+func (s *SymSpell) lookupPhonetic(
+	phrase string,
+	verbosity verbositypkg.Verbosity,
+	suggestions []items.SuggestItem,
+) []items.SuggestItem {
+	if len(suggestions) > 0 && verbosity != verbositypkg.All {
+		return suggestions
+	}
+	key := phonetic.Key(phrase)
+	if utf8.RuneCountInString(strings.ReplaceAll(key, " ", "")) < minPhoneticKeyLength {
+		return suggestions
+	}
+
+	seen := make(map[string]bool, len(suggestions))
+	for _, suggestion := range suggestions {
+		seen[suggestion.Term] = true
+	}
+	var phoneticSuggestions []items.SuggestItem
+	for _, term := range s.Phonetics[key] {
+		if seen[term] {
+			continue
+		}
+		suggestion := items.SuggestItem{
+			Term:            term,
+			Distance:        s.distanceComparer.Distance(phrase, term),
+			Count:           s.Words[term],
+			PhoneticMatched: true,
+		}
+		if s.weightedComparer != nil {
+			suggestion.WeightedDistance = s.weightedComparer.WeightedDistance(phrase, term)
+		}
+		phoneticSuggestions = append(phoneticSuggestions, suggestion)
+	}
+	s.sortByRank(phrase, phoneticSuggestions)
+	return append(suggestions, s.trimToVerbosity(phoneticSuggestions, verbosity)...)
+}
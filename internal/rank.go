@@ -0,0 +1,90 @@
+package internal
+
+import (
+	"sort"
+
+	"github.com/snapp-incubator/go-symspell/pkg/items"
+	verbositypkg "github.com/snapp-incubator/go-symspell/pkg/verbosity"
+)
+
+// lookupRanked ranks suggestions by weighted distance when a weighted edit distance is configured, and breaks
+// ties with the keyboard distance before the count when keyboard layouts are configured. Every suggestion within
+// maxEditDistance is collected for weighted ranking, since a suggestion with a larger integer distance can have
+// a lower weighted distance.
+func (s *SymSpell) lookupRanked(
+	phrase string,
+	verbosity verbositypkg.Verbosity,
+	maxEditDistance int,
+) ([]items.SuggestItem, error) {
+	if maxEditDistance > s.MaxDictionaryEditDistance {
+		return nil, errDistanceTooLarge
+	}
+	// An exact match always has the lowest distance
+	if count, found := s.Words[phrase]; found && verbosity != verbositypkg.All {
+		return []items.SuggestItem{{Term: phrase, Distance: 0, Count: count}}, nil
+	}
+
+	searchVerbosity := verbositypkg.Closest
+	if s.weightedComparer != nil {
+		searchVerbosity = verbositypkg.All
+	}
+	suggestions, err := s.lookupCandidates(phrase, max(verbosity, searchVerbosity), maxEditDistance)
+	if err != nil {
+		return nil, err
+	}
+	if s.weightedComparer != nil {
+		for i := range suggestions {
+			suggestions[i].WeightedDistance = s.weightedComparer.WeightedDistance(phrase, suggestions[i].Term)
+		}
+	}
+	s.sortByRank(phrase, suggestions)
+	return s.trimToVerbosity(suggestions, verbosity), nil
+}
+
+// rankDistance is the distance suggestions are ranked by.
+func (s *SymSpell) rankDistance(suggestion items.SuggestItem) float64 {
+	if s.weightedComparer != nil || suggestion.SkeletonMatched {
+		return suggestion.WeightedDistance
+	}
+	return float64(suggestion.Distance)
+}
+
+// sortByRank orders the suggestions of a phrase by rank distance, then by keyboard distance when keyboard layouts
+// are configured, then by count.
+func (s *SymSpell) sortByRank(phrase string, suggestions []items.SuggestItem) {
+	keyboardDistances := make(map[string]float64)
+	if s.keyboardComparer != nil {
+		for _, suggestion := range suggestions {
+			keyboardDistances[suggestion.Term] = s.keyboardComparer.WeightedDistance(phrase, suggestion.Term)
+		}
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if s.rankDistance(a) != s.rankDistance(b) {
+			return s.rankDistance(a) < s.rankDistance(b)
+		}
+		if keyboardDistances[a.Term] != keyboardDistances[b.Term] {
+			return keyboardDistances[a.Term] < keyboardDistances[b.Term]
+		}
+		return a.Count > b.Count
+	})
+}
+
+// trimToVerbosity keeps the suggestions of a ranked list that the verbosity asks for.
+func (s *SymSpell) trimToVerbosity(suggestions []items.SuggestItem, verbosity verbositypkg.Verbosity) []items.SuggestItem {
+	if len(suggestions) == 0 {
+		return suggestions
+	}
+	switch verbosity {
+	case verbositypkg.Top:
+		return suggestions[:1]
+	case verbositypkg.Closest:
+		closest := 1
+		for closest < len(suggestions) && s.rankDistance(suggestions[closest]) == s.rankDistance(suggestions[0]) {
+			closest++
+		}
+		return suggestions[:closest]
+	}
+	return suggestions
+}
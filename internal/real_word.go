@@ -0,0 +1,77 @@
+package internal
+
+import (
+	"math"
+	"strings"
+
+	verbositypkg "github.com/snapp-incubator/go-symspell/pkg/verbosity"
+)
+
+// correctRealWords replaces the suggestion parts that kept a dictionary word by a close word when the context
+// probability of the close word, given its neighbours, is at least realWordRatio times higher. Only replacements
+// forming a bigram with a neighbour are considered, word counts alone are no evidence of a real-word error. Parts
+// are corrected from left to right, so a replaced word is the context of the next one.
+func (s *SymSpell) correctRealWords(terms []string, cp *compoundProcessor, maxEditDistance int) {
+	minLogRatio := math.Log10(s.realWordRatio)
+	parts := cp.suggestionParts
+	for k, part := range parts {
+		start, end := cp.partStarts[k], len(terms)
+		if k+1 < len(cp.partStarts) {
+			end = cp.partStarts[k+1]
+		}
+		// Terms too short to change keep their placeholder count and are never corrected
+		if end-start != 1 || part.Ignored || part.Term != terms[start] || part.Distance != 0 ||
+			len([]rune(part.Term)) <= s.MinimumCharToChange {
+			continue
+		}
+		if _, found := s.Words[part.Term]; !found {
+			continue
+		}
+		left, right := "", ""
+		if k > 0 && !parts[k-1].Ignored {
+			left = lastField(parts[k-1].Term)
+		}
+		if k+1 < len(parts) && !parts[k+1].Ignored {
+			right = firstField(parts[k+1].Term)
+		}
+		if left == "" && right == "" {
+			continue
+		}
+
+		suggestions, _ := s.lookup(part.Term, verbositypkg.All, maxEditDistance)
+		threshold := s.contextLogProbability(left, part, right) + minLogRatio
+		bestScore := math.Inf(-1)
+		for _, suggestion := range suggestions {
+			if suggestion.Term == part.Term || suggestion.Distance > maxEditDistance || !s.formsBigram(left, suggestion.Term, right) {
+				continue
+			}
+			if score := s.contextLogProbability(left, suggestion, right); score >= threshold && score > bestScore {
+				bestScore = score
+				parts[k] = suggestion
+			}
+		}
+	}
+}
+
+// formsBigram reports whether a term forms a known bigram with its left or right word.
+func (s *SymSpell) formsBigram(left, term, right string) bool {
+	if _, found := s.Bigrams[left+" "+term]; found && left != "" {
+		return true
+	}
+	_, found := s.Bigrams[term+" "+right]
+	return found && right != ""
+}
+
+func firstField(text string) string {
+	if fields := strings.Fields(text); len(fields) > 0 {
+		return fields[0]
+	}
+	return ""
+}
+
+func lastField(text string) string {
+	if fields := strings.Fields(text); len(fields) > 0 {
+		return fields[len(fields)-1]
+	}
+	return ""
+}
@@ -0,0 +1,106 @@
+package internal
+
+import (
+	"github.com/snapp-incubator/go-symspell/pkg/items"
+	verbositypkg "github.com/snapp-incubator/go-symspell/pkg/verbosity"
+)
+
+// maxCollapsedRuns bounds the runs shortened to one or two repeats, further runs keep two repeats.
+const maxCollapsedRuns = 10
+
+// runeRun is a rune repeated count times.
+type runeRun struct {
+	r     rune
+	count int
+}
+
+// lookupCollapsed returns the words of the dictionary matching the phrase once its runs of repeated letters are
+// shortened to one or two repeats, it returns nothing when the phrase is a word or has no repeated letter.
+func (s *SymSpell) lookupCollapsed(phrase string, verbosity verbositypkg.Verbosity) []items.SuggestItem {
+	if _, found := s.Words[phrase]; found {
+		return nil
+	}
+	var runs []runeRun
+	repeated := 0
+	for _, r := range phrase {
+		if len(runs) > 0 && runs[len(runs)-1].r == r {
+			runs[len(runs)-1].count++
+			if runs[len(runs)-1].count == 2 {
+				repeated++
+			}
+			continue
+		}
+		runs = append(runs, runeRun{r: r, count: 1})
+	}
+	if repeated == 0 {
+		return nil
+	}
+
+	var suggestions []items.SuggestItem
+	seen := make(map[string]bool)
+	for _, candidate := range collapsedCandidates(runs, min(repeated, maxCollapsedRuns)) {
+		count, found := s.Words[candidate]
+		if !found || seen[candidate] {
+			continue
+		}
+		seen[candidate] = true
+		suggestion := items.SuggestItem{
+			Term:      candidate,
+			Distance:  len([]rune(phrase)) - len([]rune(candidate)),
+			Count:     count,
+			Collapsed: true,
+		}
+		if s.weightedComparer != nil {
+			suggestion.WeightedDistance = s.weightedComparer.WeightedDistance(phrase, candidate)
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+	s.sortByRank(phrase, suggestions)
+	return s.trimToVerbosity(suggestions, verbosity)
+}
+
+// mergeCollapsed adds the collapsed suggestions to the suggestions of the edit distance search, a word found by
+// both keeps the lower distance.
+func (s *SymSpell) mergeCollapsed(phrase string, suggestions, collapsed []items.SuggestItem) []items.SuggestItem {
+	indexes := make(map[string]int, len(suggestions))
+	for i, suggestion := range suggestions {
+		indexes[suggestion.Term] = i
+	}
+	for _, suggestion := range collapsed {
+		if i, found := indexes[suggestion.Term]; found {
+			if s.rankDistance(suggestion) < s.rankDistance(suggestions[i]) {
+				suggestions[i] = suggestion
+			}
+			continue
+		}
+		indexes[suggestion.Term] = len(suggestions)
+		suggestions = append(suggestions, suggestion)
+	}
+	s.sortByRank(phrase, suggestions)
+	return suggestions
+}
+
+// collapsedCandidates returns every spelling of the runs where each of the first collapsible repeated runs keeps one
+// or two repeats, the other runs keep at most two.
+func collapsedCandidates(runs []runeRun, collapsible int) []string {
+	candidates := make([]string, 0, 1<<collapsible)
+	for mask := 0; mask < 1<<collapsible; mask++ {
+		candidate := make([]rune, 0, len(runs)*2)
+		repeated := 0
+		for _, run := range runs {
+			repeats := 1
+			if run.count > 1 {
+				repeats = 2
+				if repeated < collapsible && mask&(1<<repeated) != 0 {
+					repeats = 1
+				}
+				repeated++
+			}
+			for range repeats {
+				candidate = append(candidate, run.r)
+			}
+		}
+		candidates = append(candidates, string(candidate))
+	}
+	return candidates
+}
@@ -0,0 +1,64 @@
+package internal
+
+import (
+	"github.com/snapp-incubator/go-symspell/pkg/items"
+	"github.com/snapp-incubator/go-symspell/pkg/normalizer"
+	verbositypkg "github.com/snapp-incubator/go-symspell/pkg/verbosity"
+)
+
+// addSkeleton indexes a word by its dotless skeleton when the skeleton index is enabled.
+func (s *SymSpell) addSkeleton(word string) {
+	if s.skeletonCost > 0 {
+		skeleton := normalizer.Skeleton(word)
+		s.Skeletons[skeleton] = append(s.Skeletons[skeleton], word)
+	}
+}
+
+// lookupSkeleton adds the words sharing the dotless skeleton of the phrase to the suggestions, at the skeleton cost
+// per letter whose dots differ, and ranks them with the suggestions found by edit distance.
+func (s *SymSpell) lookupSkeleton(
+	phrase string,
+	verbosity verbositypkg.Verbosity,
+	maxEditDistance int,
+	suggestions []items.SuggestItem,
+) []items.SuggestItem {
+	indexes := make(map[string]int, len(suggestions))
+	for i, suggestion := range suggestions {
+		indexes[suggestion.Term] = i
+	}
+	phraseRunes := []rune(phrase)
+	for _, term := range s.Skeletons[normalizer.Skeleton(phrase)] {
+		termRunes := []rune(term)
+		if len(termRunes) != len(phraseRunes) {
+			continue
+		}
+		dots := 0
+		for i := range termRunes {
+			if termRunes[i] != phraseRunes[i] {
+				dots++
+			}
+		}
+		cost := float64(dots) * s.skeletonCost
+		if cost > float64(maxEditDistance) {
+			continue
+		}
+		suggestion := items.SuggestItem{
+			Term:             term,
+			Distance:         dots,
+			Count:            s.Words[term],
+			WeightedDistance: cost,
+			SkeletonMatched:  true,
+		}
+		if i, found := indexes[term]; found {
+			if cost < s.rankDistance(suggestions[i]) {
+				suggestions[i] = suggestion
+			}
+			continue
+		}
+		indexes[term] = len(suggestions)
+		suggestions = append(suggestions, suggestion)
+	}
+
+	s.sortByRank(phrase, suggestions)
+	return s.trimToVerbosity(suggestions, verbosity)
+}
@@ -0,0 +1,332 @@
+package internal
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"maps"
+	"math"
+	"slices"
+)
+
+const (
+	snapshotMagic   = "SYMSPELL"
+	snapshotVersion = 1
+	// snapshotPrealloc bounds the capacity allocated upfront from lengths read from a snapshot
+	snapshotPrealloc = 1 << 16
+)
+
+// SaveSnapshot writes the dictionaries, the precomputed deletes and the options to w in a versioned binary
+// format ending with a CRC-32 checksum, so that LoadSnapshot can restore them without generating deletes.
+func (s *SymSpell) SaveSnapshot(w io.Writer) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	sw := newSnapshotWriter(w)
+	sw.bytes([]byte(snapshotMagic))
+	sw.uvarint(snapshotVersion)
+
+	// Options
+	sw.int(s.MaxDictionaryEditDistance)
+	sw.int(s.PrefixLength)
+	sw.int(s.CountThreshold)
+	sw.int(s.SplitThreshold)
+	sw.bool(s.PreserveCase)
+	sw.bool(s.SplitWordBySpace)
+	sw.bool(s.SplitWordAndNumber)
+	sw.int(s.MinimumCharToChange)
+	sw.int(s.maxLength)
+	sw.uvarint(math.Float64bits(s.N))
+	sw.int(s.BigramCountMin)
+
+	// Dictionaries, deletes reference words by their index in the sorted word list
+	words := slices.Sorted(maps.Keys(s.Words))
+	wordIndex := make(map[string]int, len(words))
+	sw.uvarint(uint64(len(words)))
+	for i, word := range words {
+		wordIndex[word] = i
+		sw.string(word)
+		sw.int(s.Words[word])
+	}
+	sw.countMap(s.BelowThresholdWords)
+	sw.uvarint(uint64(len(s.Deletes)))
+	for _, deleteWord := range slices.Sorted(maps.Keys(s.Deletes)) {
+		suggestions := s.Deletes[deleteWord]
+		sw.string(deleteWord)
+		sw.uvarint(uint64(len(suggestions)))
+		for _, suggestion := range suggestions {
+			sw.uvarint(uint64(wordIndex[suggestion]))
+		}
+	}
+	sw.countMap(s.Bigrams)
+	sw.uvarint(uint64(len(s.ExactTransform)))
+	for _, key := range slices.Sorted(maps.Keys(s.ExactTransform)) {
+		sw.string(key)
+		sw.string(s.ExactTransform[key])
+	}
+	return sw.close()
+}
+
+// LoadSnapshot replaces the dictionaries with the ones stored by SaveSnapshot. It fails when the snapshot
+// is corrupted or was built with a different prefix length or max dictionary edit distance.
+func (s *SymSpell) LoadSnapshot(r io.Reader) error {
+	sr := newSnapshotReader(r)
+	if magic := sr.bytes(len(snapshotMagic)); sr.err == nil && string(magic) != snapshotMagic {
+		return errors.New("not a symspell snapshot")
+	}
+	if version := sr.uvarint(); sr.err == nil && version != snapshotVersion {
+		return fmt.Errorf("unsupported snapshot version %d", version)
+	}
+
+	maxDictionaryEditDistance := sr.int()
+	prefixLength := sr.int()
+	if sr.err != nil {
+		return sr.err
+	}
+	if maxDictionaryEditDistance != s.MaxDictionaryEditDistance {
+		return fmt.Errorf("snapshot maxDictionaryEditDistance %d does not match %d",
+			maxDictionaryEditDistance, s.MaxDictionaryEditDistance)
+	}
+	if prefixLength != s.PrefixLength {
+		return fmt.Errorf("snapshot prefixLength %d does not match %d", prefixLength, s.PrefixLength)
+	}
+	// The remaining options are kept as configured on s
+	sr.int()
+	sr.int()
+	sr.bool()
+	sr.bool()
+	sr.bool()
+	sr.int()
+	maxLength := sr.int()
+	n := math.Float64frombits(sr.uvarint())
+	bigramCountMin := sr.int()
+
+	wordCount := sr.length()
+	words := make([]string, 0, min(wordCount, snapshotPrealloc))
+	wordCounts := make(map[string]int, min(wordCount, snapshotPrealloc))
+	for i := 0; i < wordCount && sr.err == nil; i++ {
+		word := sr.string()
+		wordCounts[word] = sr.int()
+		words = append(words, word)
+	}
+	belowThresholdWords := sr.countMap()
+	deleteCount := sr.length()
+	deletes := make(map[string][]string, min(deleteCount, snapshotPrealloc))
+	for i := 0; i < deleteCount && sr.err == nil; i++ {
+		deleteWord := sr.string()
+		suggestionCount := sr.length()
+		suggestions := make([]string, 0, min(suggestionCount, snapshotPrealloc))
+		for j := 0; j < suggestionCount && sr.err == nil; j++ {
+			index := sr.uvarint()
+			if index >= uint64(len(words)) {
+				sr.fail(errors.New("delete references an unknown word"))
+				break
+			}
+			suggestions = append(suggestions, words[index])
+		}
+		deletes[deleteWord] = suggestions
+	}
+	bigrams := sr.countMap()
+	exactCount := sr.length()
+	exactTransform := make(map[string]string, min(exactCount, snapshotPrealloc))
+	for i := 0; i < exactCount && sr.err == nil; i++ {
+		key := sr.string()
+		exactTransform[key] = sr.string()
+	}
+	if err := sr.verify(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Words = wordCounts
+	s.BelowThresholdWords = belowThresholdWords
+	s.Deletes = deletes
+	s.Bigrams = bigrams
+	s.ExactTransform = exactTransform
+	s.Skeletons = make(map[string][]string)
+	s.Phonetics = make(map[string][]string)
+	for _, word := range words {
+		s.addSkeleton(word)
+		s.addPhonetic(word)
+	}
+	s.maxLength = maxLength
+	s.N = n
+	s.BigramCountMin = bigramCountMin
+	return nil
+}
+
+type snapshotWriter struct {
+	w    *bufio.Writer
+	hash hash.Hash32
+	buf  [binary.MaxVarintLen64]byte
+	err  error
+}
+
+func newSnapshotWriter(w io.Writer) *snapshotWriter {
+	hash := crc32.NewIEEE()
+	return &snapshotWriter{w: bufio.NewWriter(io.MultiWriter(w, hash)), hash: hash}
+}
+
+func (w *snapshotWriter) bytes(b []byte) {
+	if w.err == nil {
+		_, w.err = w.w.Write(b)
+	}
+}
+
+func (w *snapshotWriter) uvarint(v uint64) {
+	w.bytes(w.buf[:binary.PutUvarint(w.buf[:], v)])
+}
+
+func (w *snapshotWriter) int(v int) {
+	w.bytes(w.buf[:binary.PutVarint(w.buf[:], int64(v))])
+}
+
+func (w *snapshotWriter) bool(v bool) {
+	if v {
+		w.uvarint(1)
+	} else {
+		w.uvarint(0)
+	}
+}
+
+func (w *snapshotWriter) string(v string) {
+	w.uvarint(uint64(len(v)))
+	w.bytes([]byte(v))
+}
+
+func (w *snapshotWriter) countMap(m map[string]int) {
+	w.uvarint(uint64(len(m)))
+	for _, key := range slices.Sorted(maps.Keys(m)) {
+		w.string(key)
+		w.int(m[key])
+	}
+}
+
+// close flushes the data and appends its checksum.
+func (w *snapshotWriter) close() error {
+	if w.err == nil {
+		w.err = w.w.Flush()
+	}
+	if w.err != nil {
+		return w.err
+	}
+	_, err := w.w.Write(binary.BigEndian.AppendUint32(nil, w.hash.Sum32()))
+	if err != nil {
+		return err
+	}
+	return w.w.Flush()
+}
+
+// snapshotReader decodes a snapshot, the first error is kept and makes every following read a no-op.
+type snapshotReader struct {
+	r    *bufio.Reader
+	hash hash.Hash32
+	err  error
+}
+
+func newSnapshotReader(r io.Reader) *snapshotReader {
+	return &snapshotReader{r: bufio.NewReader(r), hash: crc32.NewIEEE()}
+}
+
+func (r *snapshotReader) fail(err error) {
+	if r.err == nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		r.err = fmt.Errorf("corrupted snapshot: %w", err)
+	}
+}
+
+// ReadByte implements io.ByteReader for binary.ReadUvarint while updating the checksum.
+func (r *snapshotReader) ReadByte() (byte, error) {
+	b, err := r.r.ReadByte()
+	if err == nil {
+		_, _ = r.hash.Write([]byte{b})
+	}
+	return b, err
+}
+
+func (r *snapshotReader) bytes(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r.r, b); err != nil {
+		r.fail(err)
+		return nil
+	}
+	_, _ = r.hash.Write(b)
+	return b
+}
+
+func (r *snapshotReader) uvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	v, err := binary.ReadUvarint(r)
+	if err != nil {
+		r.fail(err)
+	}
+	return v
+}
+
+func (r *snapshotReader) int() int {
+	if r.err != nil {
+		return 0
+	}
+	v, err := binary.ReadVarint(r)
+	if err != nil {
+		r.fail(err)
+	}
+	return int(v)
+}
+
+func (r *snapshotReader) bool() bool {
+	return r.uvarint() != 0
+}
+
+// length reads a collection length, bounded to avoid huge allocations on corrupted input.
+func (r *snapshotReader) length() int {
+	v := r.uvarint()
+	if v > math.MaxInt32 {
+		r.fail(fmt.Errorf("invalid length %d", v))
+		return 0
+	}
+	return int(v)
+}
+
+func (r *snapshotReader) string() string {
+	return string(r.bytes(r.length()))
+}
+
+func (r *snapshotReader) countMap() map[string]int {
+	count := r.length()
+	m := make(map[string]int, min(count, snapshotPrealloc))
+	for i := 0; i < count && r.err == nil; i++ {
+		key := r.string()
+		m[key] = r.int()
+	}
+	return m
+}
+
+// verify compares the checksum of the data read so far with the one stored at the end of the snapshot.
+func (r *snapshotReader) verify() error {
+	if r.err != nil {
+		return r.err
+	}
+	sum := r.hash.Sum32()
+	checksum := make([]byte, 4)
+	if _, err := io.ReadFull(r.r, checksum); err != nil {
+		r.fail(err)
+		return r.err
+	}
+	if binary.BigEndian.Uint32(checksum) != sum {
+		return errors.New("corrupted snapshot: checksum mismatch")
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package internal
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/snapp-incubator/go-symspell/pkg/options"
+	verbositypkg "github.com/snapp-incubator/go-symspell/pkg/verbosity"
+)
+
+func newSnapshotSymSpell(t *testing.T, opt ...options.Options) *SymSpell {
+	t.Helper()
+	symSpell, err := NewSymSpell(opt...)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return symSpell
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	symSpell := newSnapshotSymSpell(t, options.WithCountThreshold(5))
+	symSpell.createDictionaryEntry("خیابان", 100)
+	symSpell.createDictionaryEntry("کارگر", 50)
+	symSpell.createDictionaryEntry("steam", 2)
+	symSpell.Bigrams["خیابان کارگر"] = 20
+	symSpell.BigramCountMin = 20
+	symSpell.ExactTransform["خ"] = "خیابان"
+
+	var buf bytes.Buffer
+	if err := symSpell.SaveSnapshot(&buf); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	loaded := newSnapshotSymSpell(t, options.WithCountThreshold(5))
+	if err := loaded.LoadSnapshot(bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(loaded.Words, symSpell.Words) ||
+		!reflect.DeepEqual(loaded.BelowThresholdWords, symSpell.BelowThresholdWords) ||
+		!reflect.DeepEqual(loaded.Bigrams, symSpell.Bigrams) ||
+		!reflect.DeepEqual(loaded.ExactTransform, symSpell.ExactTransform) {
+		t.Errorf("Expected dictionaries to be restored")
+	}
+	if len(loaded.Deletes) != len(symSpell.Deletes) {
+		t.Errorf("Expected %d deletes, got %d", len(symSpell.Deletes), len(loaded.Deletes))
+	}
+	if loaded.maxLength != symSpell.maxLength || loaded.N != symSpell.N || loaded.BigramCountMin != 20 {
+		t.Errorf("Expected counters to be restored")
+	}
+	results, _ := loaded.Lookup("حیابان", verbositypkg.Top, 2)
+	if len(results) != 1 || results[0].Term != "خیابان" {
+		t.Errorf("Expected 'خیابان', got %v", results)
+	}
+}
+
+func TestSnapshotOptionMismatch(t *testing.T) {
+	symSpell := newSnapshotSymSpell(t, options.WithPrefixLength(7))
+	symSpell.createDictionaryEntry("steam", 2)
+	var buf bytes.Buffer
+	if err := symSpell.SaveSnapshot(&buf); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	err := newSnapshotSymSpell(t, options.WithPrefixLength(5)).LoadSnapshot(bytes.NewReader(buf.Bytes()))
+	if err == nil || !strings.Contains(err.Error(), "prefixLength") {
+		t.Errorf("Expected prefix length error, got %v", err)
+	}
+	err = newSnapshotSymSpell(t, options.WithMaxDictionaryEditDistance(1)).LoadSnapshot(bytes.NewReader(buf.Bytes()))
+	if err == nil || !strings.Contains(err.Error(), "maxDictionaryEditDistance") {
+		t.Errorf("Expected max distance error, got %v", err)
+	}
+}
+
+func TestSnapshotCorrupted(t *testing.T) {
+	symSpell := newSnapshotSymSpell(t)
+	symSpell.createDictionaryEntry("steam", 2)
+	var buf bytes.Buffer
+	if err := symSpell.SaveSnapshot(&buf); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	data := buf.Bytes()
+
+	corrupted := bytes.Clone(data)
+	corrupted[len(corrupted)-6] ^= 0xff
+	if err := newSnapshotSymSpell(t).LoadSnapshot(bytes.NewReader(corrupted)); err == nil {
+		t.Errorf("Expected checksum error")
+	}
+	if err := newSnapshotSymSpell(t).LoadSnapshot(bytes.NewReader(data[:len(data)/2])); err == nil {
+		t.Errorf("Expected error for truncated snapshot")
+	}
+	if err := newSnapshotSymSpell(t).LoadSnapshot(strings.NewReader("steam 2\n")); err == nil {
+		t.Errorf("Expected error for non snapshot input")
+	}
+}
@@ -2,21 +2,37 @@ package internal
 
 import (
 	"bufio"
+	"compress/gzip"
 	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"log"
 	"math"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"unicode/utf8"
 
 	"github.com/snapp-incubator/go-symspell/pkg/editdistance"
+	"github.com/snapp-incubator/go-symspell/pkg/ignore"
+	"github.com/snapp-incubator/go-symspell/pkg/loadresult"
+	"github.com/snapp-incubator/go-symspell/pkg/morphology"
+	"github.com/snapp-incubator/go-symspell/pkg/normalizer"
 	"github.com/snapp-incubator/go-symspell/pkg/options"
+	"github.com/snapp-incubator/go-symspell/pkg/phonetic"
+	"github.com/snapp-incubator/go-symspell/pkg/transliteration"
 )
 
 // SymSpell represents the Symmetric Delete spelling correction algorithm.
+//
+// A SymSpell is safe for concurrent use: lookups share a read lock while loading dictionaries takes the
+// write lock. The exported maps are not guarded when accessed directly, so they must not be read or
+// modified while the SymSpell is used from several goroutines.
 type SymSpell struct {
+	mu                        sync.RWMutex
 	MaxDictionaryEditDistance int
 	PrefixLength              int
 	CountThreshold            int
@@ -31,10 +47,25 @@ type SymSpell struct {
 	ExactTransform            map[string]string
 	maxLength                 int
 	distanceComparer          editdistance.IEditDistance
+	weightedComparer          editdistance.IWeightedEditDistance
+	keyboardComparer          editdistance.IWeightedEditDistance
+	layoutSwaps               []*editdistance.KeyboardLayout
+	normalizer                normalizer.Normalizer
+	affixes                   *morphology.AffixTable
+	skeletonCost              float64
+	phoneticIndex             bool
+	transliterator            *transliteration.Transliterator
+	collapseRepeats           bool
+	ignoreRules               *ignore.Rules
+	realWordRatio             float64
 	// lookup compound
 	N              float64
 	Bigrams        map[string]int
 	BigramCountMin int
+	// Skeletons maps dotless skeletons to the words sharing them, it is only filled when the index is enabled.
+	Skeletons map[string][]string
+	// Phonetics maps phonetic keys to the words sounding alike, it is only filled when the index is enabled.
+	Phonetics map[string][]string
 }
 
 // NewSymSpell is the constructor for the SymSpell struct.
@@ -55,6 +86,26 @@ func NewSymSpell(opt ...options.Options) (*SymSpell, error) {
 	if opts.CountThreshold < 0 {
 		return nil, errors.New("countThreshold cannot be negative")
 	}
+	if opts.SkeletonCost < 0 {
+		return nil, errors.New("skeletonCost cannot be negative")
+	}
+	if opts.RealWordRatio != 0 && opts.RealWordRatio < 1 {
+		return nil, errors.New("realWordRatio cannot be less than 1")
+	}
+	if opts.EditDistance == nil {
+		return nil, errors.New("editDistance cannot be nil")
+	}
+
+	weightedComparer := opts.WeightedEditDistance
+	if distance, ok := opts.EditDistance.(*editdistance.EditDistance); ok && distance.Type == editdistance.JaroWinkler &&
+		weightedComparer == nil {
+		// Jaro-Winkler distances are rounded, suggestions are ranked by the similarity itself
+		weightedComparer = editdistance.JaroWinklerWeighted{}
+	}
+	var keyboardComparer editdistance.IWeightedEditDistance
+	if len(opts.KeyboardLayouts) > 0 {
+		keyboardComparer = editdistance.NewKeyboardWeighted(opts.KeyboardLayouts...)
+	}
 
 	return &SymSpell{
 		MaxDictionaryEditDistance: opts.MaxDictionaryEditDistance,
@@ -69,7 +120,20 @@ func NewSymSpell(opt ...options.Options) (*SymSpell, error) {
 		BelowThresholdWords:       make(map[string]int),
 		Deletes:                   make(map[string][]string),
 		ExactTransform:            make(map[string]string),
-		distanceComparer:          editdistance.NewEditDistance(editdistance.DamerauLevenshtein), // todo add more edit distance algorithms
+		Skeletons:                 make(map[string][]string),
+		Phonetics:                 make(map[string][]string),
+		distanceComparer:          opts.EditDistance,
+		weightedComparer:          weightedComparer,
+		keyboardComparer:          keyboardComparer,
+		layoutSwaps:               opts.LayoutSwaps,
+		normalizer:                opts.Normalizer,
+		affixes:                   opts.Affixes,
+		skeletonCost:              opts.SkeletonCost,
+		phoneticIndex:             opts.PhoneticIndex,
+		transliterator:            opts.Transliterator,
+		collapseRepeats:           opts.CollapseRepeats,
+		ignoreRules:               opts.IgnoreRules,
+		realWordRatio:             opts.RealWordRatio,
 		maxLength:                 0,
 		Bigrams:                   make(map[string]int),
 		N:                         1024908267229,
@@ -88,18 +152,16 @@ func (s *SymSpell) createDictionaryEntry(key string, count int) bool {
 	}
 
 	// Check below-threshold words
-	if s.CountThreshold > 1 {
-		if countPrev, found := s.BelowThresholdWords[key]; found {
-			// Increment the count
-			count = incrementCount(count, countPrev)
-			// Check if it reaches the threshold
-			if count < s.CountThreshold {
-				s.BelowThresholdWords[key] = count
-				return false
-
-			}
-			delete(s.BelowThresholdWords, key)
+	if countPrev, found := s.BelowThresholdWords[key]; s.CountThreshold > 1 && found {
+		// Increment the count
+		count = incrementCount(count, countPrev)
+		// Check if it reaches the threshold
+		if count < s.CountThreshold {
+			s.BelowThresholdWords[key] = count
+			return false
+
 		}
+		delete(s.BelowThresholdWords, key)
 	} else if countPrev, found := s.Words[key]; found {
 		// Increment the count
 		s.Words[key] = incrementCount(count, countPrev)
@@ -115,8 +177,8 @@ func (s *SymSpell) createDictionaryEntry(key string, count int) bool {
 	s.Words[key] = count
 
 	// Update max length
-	if len(key) > s.maxLength {
-		s.maxLength = len(key)
+	if keyLen := utf8.RuneCountInString(key); keyLen > s.maxLength {
+		s.maxLength = keyLen
 	}
 
 	// Create deletes
@@ -124,10 +186,112 @@ func (s *SymSpell) createDictionaryEntry(key string, count int) bool {
 	for deleteWord := range edits {
 		s.Deletes[deleteWord] = append(s.Deletes[deleteWord], key)
 	}
+	s.addSkeleton(key)
+	s.addPhonetic(key)
 
 	return true
 }
 
+// CreateDictionaryEntry adds a word to the dictionary or increments the count of an existing one,
+// it returns true when a new word has been added to Words.
+func (s *SymSpell) CreateDictionaryEntry(term string, count int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.createDictionaryEntry(s.normalize(term), count)
+}
+
+// DeleteDictionaryEntry removes a word and its deletes from the dictionary,
+// it returns false when the word does not exist.
+func (s *SymSpell) DeleteDictionaryEntry(term string) bool {
+	term = s.normalize(term)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, found := s.BelowThresholdWords[term]; found {
+		delete(s.BelowThresholdWords, term)
+		return true
+	}
+	return s.deleteDictionaryEntry(term)
+}
+
+// IncrementCount adds delta to the count of a word, a negative delta decrements it. Words whose count falls
+// below CountThreshold are moved to BelowThresholdWords and words whose count drops under zero are removed.
+// It reports whether the word is part of Words after the update.
+func (s *SymSpell) IncrementCount(term string, delta int) bool {
+	term = s.normalize(term)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if delta >= 0 {
+		s.createDictionaryEntry(term, delta)
+		_, found := s.Words[term]
+		return found
+	}
+
+	countPrev, inWords := s.Words[term]
+	if !inWords {
+		var found bool
+		if countPrev, found = s.BelowThresholdWords[term]; !found {
+			return false
+		}
+	}
+	count := countPrev + delta
+	if count < 0 || (count == 0 && s.CountThreshold > 0) {
+		delete(s.BelowThresholdWords, term)
+		s.deleteDictionaryEntry(term)
+		return false
+	}
+	if count < s.CountThreshold {
+		s.deleteDictionaryEntry(term)
+		s.BelowThresholdWords[term] = count
+		return false
+	}
+	s.Words[term] = count
+	return true
+}
+
+// normalize applies the configured normalizer to a term or a query.
+func (s *SymSpell) normalize(text string) string {
+	if s.normalizer == nil {
+		return text
+	}
+	return s.normalizer.Normalize(text)
+}
+
+// deleteDictionaryEntry removes a word from Words and Deletes and updates the max length.
+func (s *SymSpell) deleteDictionaryEntry(key string) bool {
+	if _, found := s.Words[key]; !found {
+		return false
+	}
+	delete(s.Words, key)
+
+	// Remove the word from its deletes
+	for deleteWord := range s.editsPrefix(key) {
+		removeFromIndex(s.Deletes, deleteWord, key)
+	}
+	removeFromIndex(s.Skeletons, normalizer.Skeleton(key), key)
+	removeFromIndex(s.Phonetics, phonetic.Key(key), key)
+
+	// Update max length
+	if utf8.RuneCountInString(key) == s.maxLength {
+		s.maxLength = 0
+		for word := range s.Words {
+			s.maxLength = max(s.maxLength, utf8.RuneCountInString(word))
+		}
+	}
+	return true
+}
+
+// removeFromIndex removes a word from the words listed under key, and the key once no word is left.
+func removeFromIndex(index map[string][]string, key, word string) {
+	words := slices.DeleteFunc(index[key], func(indexed string) bool {
+		return indexed == word
+	})
+	if len(words) == 0 {
+		delete(index, key)
+	} else {
+		index[key] = words
+	}
+}
+
 func (s *SymSpell) edits(word string, editDistance int, deleteWords map[string]bool, currentDistance int) {
 	editDistance++
 	runes := []rune(word)
@@ -175,35 +339,138 @@ func (s *SymSpell) LoadDictionary(corpusPath string, termIndex int, countIndex i
 		return false, nil
 	}
 
-	// Open the file
-	file, err := os.Open(corpusPath)
+	result, err := s.LoadDictionaryWithResult(corpusPath, termIndex, countIndex, separator)
+	return result.Loaded, err
+}
+
+// LoadDictionaryWithResult loads dictionary entries from a file and reports how many lines were accepted or skipped.
+func (s *SymSpell) LoadDictionaryWithResult(corpusPath string, termIndex int, countIndex int, separator string) (loadresult.Dictionary, error) {
+	return s.loadDictionaryFile(nil, corpusPath, termIndex, countIndex, separator)
+}
+
+// LoadDictionaryFS loads dictionary entries from a file of fsys, such as an embed.FS.
+func (s *SymSpell) LoadDictionaryFS(fsys fs.FS, corpusPath string, termIndex int, countIndex int, separator string) (bool, error) {
+	result, err := s.loadDictionaryFile(fsys, corpusPath, termIndex, countIndex, separator)
+	return result.Loaded, err
+}
+
+// LoadDictionaryStream loads dictionary entries from a stream.
+func (s *SymSpell) LoadDictionaryStream(corpusStream io.Reader, termIndex int, countIndex int, separator string) (bool, error) {
+	result := loadresult.NewDictionary(loadresult.Unigram, "")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err := s.loadDictionaryStream(corpusStream, termIndex, countIndex, separator, &result)
+	return result.Loaded, err
+}
+
+func (s *SymSpell) loadDictionaryFile(
+	fsys fs.FS,
+	corpusPath string,
+	termIndex, countIndex int,
+	separator string,
+) (loadresult.Dictionary, error) {
+	result := loadresult.NewDictionary(loadresult.Unigram, corpusPath)
+	file, err := openCorpus(fsys, corpusPath)
 	if err != nil {
-		return false, err
+		result.Err = err
+		return result, err
 	}
 	defer file.Close()
 
-	// Load dictionary data from file
-	scanner := bufio.NewScanner(file)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err = s.loadDictionaryStream(file, termIndex, countIndex, separator, &result)
+	return result, err
+}
+
+func (s *SymSpell) loadDictionaryStream(
+	corpusStream io.Reader,
+	termIndex, countIndex int,
+	separator string,
+	result *loadresult.Dictionary,
+) error {
+	corpusStream, err := decompressCorpus(corpusStream)
+	if err != nil {
+		result.Err = err
+		return err
+	}
+	// Load dictionary data from stream
+	scanner := bufio.NewScanner(corpusStream)
 	for scanner.Scan() {
 		line := scanner.Text()
 		fields := strings.Split(line, separator)
 		if len(fields) <= max(termIndex, countIndex) {
-			continue // Skip invalid lines
+			result.Skip(loadresult.MissingFields) // Skip invalid lines
+			continue
 		}
 
-		term := fields[termIndex]
+		term := s.normalize(fields[termIndex])
 		count, err := strconv.Atoi(fields[countIndex])
 		if err != nil {
-			continue // Skip invalid counts
+			result.Skip(loadresult.InvalidCount) // Skip invalid counts
+			continue
 		}
 		s.createDictionaryEntry(term, count)
+		result.Accept()
 	}
 
-	if err = scanner.Err(); err != nil {
-		return false, err
+	if err := scanner.Err(); err != nil {
+		result.Err = err
+		return err
+	}
+
+	result.Loaded = true
+	return nil
+}
+
+// openCorpus opens a dictionary file of fsys, or of the local file system when fsys is nil.
+// Files with a .gz suffix are decompressed while reading.
+func openCorpus(fsys fs.FS, corpusPath string) (io.ReadCloser, error) {
+	if corpusPath == "" {
+		return nil, errors.New("corpus path cannot be empty")
+	}
+	var file io.ReadCloser
+	var err error
+	if fsys == nil {
+		file, err = os.Open(corpusPath)
+	} else {
+		file, err = fsys.Open(corpusPath)
+	}
+	if err != nil || !strings.HasSuffix(corpusPath, ".gz") {
+		return file, err
+	}
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("reading gzip dictionary %s: %w", corpusPath, err)
 	}
+	return gzipCorpus{Reader: gzipReader, file: file}, nil
+}
+
+// gzipCorpus closes both the gzip reader and the underlying file.
+type gzipCorpus struct {
+	*gzip.Reader
+	file io.Closer
+}
+
+func (c gzipCorpus) Close() error {
+	return errors.Join(c.Reader.Close(), c.file.Close())
+}
 
-	return true, nil
+// decompressCorpus returns a reader decompressing corpusStream when it starts with the gzip magic bytes,
+// otherwise the buffered stream is returned as is. A file already decompressed by openCorpus is not sniffed again,
+// its content is read as is even when it starts with the magic bytes.
+func decompressCorpus(corpusStream io.Reader) (io.Reader, error) {
+	if _, decompressed := corpusStream.(gzipCorpus); decompressed {
+		return corpusStream, nil
+	}
+	buffered := bufio.NewReader(corpusStream)
+	magic, err := buffered.Peek(2)
+	if err != nil || magic[0] != 0x1f || magic[1] != 0x8b {
+		// Streams shorter than the magic bytes are read as plain text
+		return buffered, nil
+	}
+	return gzip.NewReader(buffered)
 }
 
 func incrementCount(count, countPrevious int) int {
@@ -218,26 +485,58 @@ func (s *SymSpell) LoadExactDictionary(
 	corpusPath string,
 	separator string,
 ) (bool, error) {
-	if corpusPath == "" {
-		return false, fmt.Errorf("corpus path cannot be empty")
-	}
-	// Check if the file exists
-	file, err := os.Open(corpusPath)
+	result, err := s.LoadExactDictionaryWithResult(corpusPath, separator)
+	return result.Loaded, err
+}
+
+// LoadExactDictionaryWithResult loads exact transforms from a file and reports how many lines were accepted or skipped.
+func (s *SymSpell) LoadExactDictionaryWithResult(corpusPath string, separator string) (loadresult.Dictionary, error) {
+	return s.loadExactDictionaryFile(nil, corpusPath, separator)
+}
+
+// LoadExactDictionaryFS loads exact transforms from a file of fsys, such as an embed.FS.
+func (s *SymSpell) LoadExactDictionaryFS(fsys fs.FS, corpusPath string, separator string) (bool, error) {
+	result, err := s.loadExactDictionaryFile(fsys, corpusPath, separator)
+	return result.Loaded, err
+}
+
+// LoadExactDictionaryStream loads exact transforms from a stream.
+func (s *SymSpell) LoadExactDictionaryStream(corpusStream io.Reader, separator string) (bool, error) {
+	result := loadresult.NewDictionary(loadresult.Exact, "")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err := s.loadExactDictionaryStream(corpusStream, separator, &result)
+	return result.Loaded, err
+}
+
+func (s *SymSpell) loadExactDictionaryFile(fsys fs.FS, corpusPath string, separator string) (loadresult.Dictionary, error) {
+	result := loadresult.NewDictionary(loadresult.Exact, corpusPath)
+	file, err := openCorpus(fsys, corpusPath)
 	if err != nil {
-		return false, err
+		result.Err = err
+		return result, err
 	}
 	defer file.Close()
 
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	// Use the stream-based loading function
-	return s.LoadExactDictionaryStream(file, separator), nil
+	err = s.loadExactDictionaryStream(file, separator, &result)
+	return result, err
 }
 
-func (s *SymSpell) LoadExactDictionaryStream(corpusStream *os.File, separator string) bool {
+func (s *SymSpell) loadExactDictionaryStream(corpusStream io.Reader, separator string, result *loadresult.Dictionary) error {
+	corpusStream, err := decompressCorpus(corpusStream)
+	if err != nil {
+		result.Err = err
+		return err
+	}
 	scanner := bufio.NewScanner(corpusStream)
 	// Define minimum parts depending on the separator
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
 		if line == "" {
+			result.Skip(loadresult.EmptyLine)
 			continue
 		}
 		// Split line by the separator
@@ -248,14 +547,21 @@ func (s *SymSpell) LoadExactDictionaryStream(corpusStream *os.File, separator st
 			parts = strings.Split(line, separator)
 		}
 		if len(parts) < 2 {
+			result.Skip(loadresult.MissingFields)
 			continue
 		}
 		// Parse count
-		exactMatch := parts[1]
+		exactMatch := s.normalize(parts[1])
 		// Create the key
-		key := parts[0]
+		key := s.normalize(parts[0])
 		// Add to Exact Transform dictionary
 		s.ExactTransform[key] = exactMatch
+		result.Accept()
 	}
-	return true
+	if err := scanner.Err(); err != nil {
+		result.Err = err
+		return err
+	}
+	result.Loaded = true
+	return nil
 }
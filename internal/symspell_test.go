@@ -0,0 +1,235 @@
+package internal
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+
+	"github.com/snapp-incubator/go-symspell/pkg/options"
+	verbositypkg "github.com/snapp-incubator/go-symspell/pkg/verbosity"
+)
+
+// TestConcurrentLookupAndLoad should be run with -race to detect unsynchronized access.
+func TestConcurrentLookupAndLoad(t *testing.T) {
+	symSpell, err := NewSymSpell(options.WithCountThreshold(1))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	symSpell.createDictionaryEntry("steam", 10)
+	symSpell.createDictionaryEntry("plan", 10)
+
+	var lines strings.Builder
+	for i := range 200 {
+		fmt.Fprintf(&lines, "word%d %d\n", i, i+1)
+	}
+	dir := t.TempDir()
+	vocabPath := filepath.Join(dir, "vocab.txt")
+	if err := os.WriteFile(vocabPath, []byte(lines.String()), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	bigramPath := filepath.Join(dir, "bigram.txt")
+	if err := os.WriteFile(bigramPath, []byte("steam plan 5\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 50 {
+				results, err := symSpell.Lookup("stean", verbositypkg.Top, 2)
+				if err != nil || len(results) == 0 || results[0].Term != "steam" {
+					t.Errorf("Unexpected lookup result %v, %v", results, err)
+					return
+				}
+				symSpell.LookupCompound("stean pln", 2)
+				symSpell.WordSegmentation("steamplan", 1, 10)
+			}
+		}()
+	}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		if _, err := symSpell.LoadDictionary(vocabPath, 0, 1, " "); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		if _, err := symSpell.LoadBigramDictionary(bigramPath, 0, 2, ""); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	}()
+	wg.Wait()
+
+	results, err := symSpell.Lookup("word199", verbositypkg.Top, 0)
+	if err != nil || len(results) != 1 {
+		t.Fatalf("Expected loaded word, got %v, %v", results, err)
+	}
+}
+
+func TestDictionaryEntryUpdates(t *testing.T) {
+	symSpell, err := NewSymSpell(options.WithCountThreshold(10), options.WithMaxDictionaryEditDistance(2))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	symSpell.CreateDictionaryEntry("steam", 20)
+	symSpell.CreateDictionaryEntry("steams", 5)
+
+	if _, found := symSpell.BelowThresholdWords["steams"]; !found {
+		t.Fatalf("Expected 'steams' to be below threshold")
+	}
+	if !symSpell.IncrementCount("steams", 5) {
+		t.Fatalf("Expected 'steams' to reach the threshold")
+	}
+	if symSpell.Words["steams"] != 10 {
+		t.Errorf("Expected count 10, got %d", symSpell.Words["steams"])
+	}
+	if symSpell.CreateDictionaryEntry("steam", 5) || symSpell.Words["steam"] != 25 {
+		t.Errorf("Expected existing word count to be incremented, got %d", symSpell.Words["steam"])
+	}
+
+	if symSpell.IncrementCount("steams", -3) {
+		t.Fatalf("Expected 'steams' to fall below the threshold")
+	}
+	if symSpell.BelowThresholdWords["steams"] != 7 {
+		t.Errorf("Expected below threshold count 7, got %d", symSpell.BelowThresholdWords["steams"])
+	}
+	results, _ := symSpell.Lookup("steams", verbositypkg.Top, 2)
+	if len(results) != 1 || results[0].Term != "steam" {
+		t.Errorf("Expected 'steam', got %v", results)
+	}
+
+	if !symSpell.DeleteDictionaryEntry("steam") {
+		t.Fatalf("Expected 'steam' to be deleted")
+	}
+	if symSpell.DeleteDictionaryEntry("steam") {
+		t.Errorf("Expected second delete to fail")
+	}
+	results, _ = symSpell.Lookup("steams", verbositypkg.Top, 2)
+	if len(results) != 0 {
+		t.Errorf("Expected no results, got %v", results)
+	}
+	for deleteWord, suggestions := range symSpell.Deletes {
+		t.Errorf("Expected no deletes, got %s: %v", deleteWord, suggestions)
+	}
+	if symSpell.maxLength != 0 {
+		t.Errorf("Expected max length 0, got %d", symSpell.maxLength)
+	}
+}
+
+func TestDuplicateEntriesAboveThreshold(t *testing.T) {
+	symSpell, _ := NewSymSpell(options.WithCountThreshold(2))
+	symSpell.createDictionaryEntry("pawn", 3)
+	symSpell.createDictionaryEntry("pawn", 4)
+
+	if symSpell.Words["pawn"] != 7 {
+		t.Errorf("Expected count 7, got %d", symSpell.Words["pawn"])
+	}
+	if len(symSpell.Deletes["pawn"]) != 1 {
+		t.Errorf("Expected a single delete entry, got %v", symSpell.Deletes["pawn"])
+	}
+}
+
+func TestLoadFromStreamAndFS(t *testing.T) {
+	symSpell, err := NewSymSpell(options.WithCountThreshold(1))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	ok, err := symSpell.LoadDictionaryStream(strings.NewReader("steam 10\nplan 5\n"), 0, 1, " ")
+	if !ok || err != nil {
+		t.Fatalf("Expected dictionary stream to load, got %v", err)
+	}
+	ok, err = symSpell.LoadBigramDictionaryStream(strings.NewReader("steam plan 3\n"), 0, 2, "")
+	if !ok || err != nil {
+		t.Fatalf("Expected bigram stream to load, got %v", err)
+	}
+	ok, err = symSpell.LoadExactDictionaryStream(strings.NewReader("st steam\n"), " ")
+	if !ok || err != nil {
+		t.Fatalf("Expected exact stream to load, got %v", err)
+	}
+	if symSpell.Words["plan"] != 5 || symSpell.Bigrams["steam plan"] != 3 || symSpell.ExactTransform["st"] != "steam" {
+		t.Errorf("Unexpected dictionaries %v %v %v", symSpell.Words, symSpell.Bigrams, symSpell.ExactTransform)
+	}
+
+	fsys := fstest.MapFS{
+		"dict/vocab.txt":  {Data: []byte("pipe 4\n")},
+		"dict/bigram.txt": {Data: []byte("pipe plan 2\n")},
+		"dict/exact.txt":  {Data: []byte("pp pipe\n")},
+	}
+	if ok, err = symSpell.LoadDictionaryFS(fsys, "dict/vocab.txt", 0, 1, " "); !ok || err != nil {
+		t.Fatalf("Expected dictionary file to load, got %v", err)
+	}
+	if ok, err = symSpell.LoadBigramDictionaryFS(fsys, "dict/bigram.txt", 0, 2, ""); !ok || err != nil {
+		t.Fatalf("Expected bigram file to load, got %v", err)
+	}
+	if ok, err = symSpell.LoadExactDictionaryFS(fsys, "dict/exact.txt", " "); !ok || err != nil {
+		t.Fatalf("Expected exact file to load, got %v", err)
+	}
+	if symSpell.Words["pipe"] != 4 || symSpell.Bigrams["pipe plan"] != 2 || symSpell.ExactTransform["pp"] != "pipe" {
+		t.Errorf("Unexpected dictionaries %v %v %v", symSpell.Words, symSpell.Bigrams, symSpell.ExactTransform)
+	}
+	if _, err = symSpell.LoadDictionaryFS(fsys, "dict/missing.txt", 0, 1, " "); err == nil {
+		t.Errorf("Expected error for missing file")
+	}
+}
+
+func TestLoadGzipDictionaries(t *testing.T) {
+	gzipped := func(data string) []byte {
+		var buf bytes.Buffer
+		writer := gzip.NewWriter(&buf)
+		if _, err := writer.Write([]byte(data)); err != nil {
+			t.Fatal(err)
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+	symSpell, err := NewSymSpell(options.WithCountThreshold(1))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Detected by magic bytes
+	if ok, err := symSpell.LoadDictionaryStream(bytes.NewReader(gzipped("steam 10\n")), 0, 1, " "); !ok || err != nil {
+		t.Fatalf("Expected gzip stream to load, got %v", err)
+	}
+	// Detected by suffix
+	dir := t.TempDir()
+	bigramPath := filepath.Join(dir, "bigram.txt.gz")
+	if err := os.WriteFile(bigramPath, gzipped("steam plan 3\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := symSpell.LoadBigramDictionary(bigramPath, 0, 2, ""); !ok || err != nil {
+		t.Fatalf("Expected gzip file to load, got %v", err)
+	}
+	fsys := fstest.MapFS{
+		"exact.txt.gz":   {Data: gzipped("st steam\n")},
+		"invalid.txt.gz": {Data: []byte("st steam\n")},
+	}
+	if ok, err := symSpell.LoadExactDictionaryFS(fsys, "exact.txt.gz", " "); !ok || err != nil {
+		t.Fatalf("Expected gzip file to load, got %v", err)
+	}
+	if _, err := symSpell.LoadExactDictionaryFS(fsys, "invalid.txt.gz", " "); err == nil {
+		t.Errorf("Expected error for invalid gzip file")
+	}
+	// A file detected by its suffix is decompressed once, even when its content starts with the magic bytes
+	fsys["nested.txt.gz"] = &fstest.MapFile{Data: gzipped(string(gzipped("nested 5\n")))}
+	if _, err := symSpell.LoadDictionaryFS(fsys, "nested.txt.gz", 0, 1, " "); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, found := symSpell.Words["nested"]; found {
+		t.Errorf("Expected a gzip file to be decompressed once")
+	}
+	if symSpell.Words["steam"] != 10 || symSpell.Bigrams["steam plan"] != 3 || symSpell.ExactTransform["st"] != "steam" {
+		t.Errorf("Unexpected dictionaries %v %v %v", symSpell.Words, symSpell.Bigrams, symSpell.ExactTransform)
+	}
+}
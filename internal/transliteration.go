@@ -0,0 +1,46 @@
+package internal
+
+import "github.com/snapp-incubator/go-symspell/pkg/transliteration"
+
+// transliterate replaces the Latin-script terms missing from Words and not ignored with their best Persian
+// transliteration, it reports whether any term was replaced.
+func (s *SymSpell) transliterate(terms []string, ignored []bool) bool {
+	if s.transliterator == nil {
+		return false
+	}
+	replaced := false
+	for i, term := range terms {
+		if _, found := s.Words[term]; found || ignored[i] || !transliteration.IsLatin(term) {
+			continue
+		}
+		previous := ""
+		if i > 0 {
+			previous = terms[i-1]
+		}
+		if candidate, found := s.bestTransliteration(previous, term); found {
+			terms[i] = candidate
+			replaced = true
+		}
+	}
+	return replaced
+}
+
+// bestTransliteration scores the transliteration candidates of a term found in Words, preferring the candidate
+// forming the most frequent bigram with the previous term, then the most frequent word.
+func (s *SymSpell) bestTransliteration(previous, term string) (string, bool) {
+	best, bestBigramCount, bestCount := "", 0, 0
+	for _, candidate := range s.transliterator.Candidates(term) {
+		count, found := s.Words[candidate]
+		if !found {
+			continue
+		}
+		bigramCount := 0
+		if previous != "" {
+			bigramCount = s.Bigrams[previous+" "+candidate]
+		}
+		if bigramCount > bestBigramCount || (bigramCount == bestBigramCount && count > bestCount) {
+			best, bestBigramCount, bestCount = candidate, bigramCount, count
+		}
+	}
+	return best, best != ""
+}
@@ -0,0 +1,98 @@
+package internal
+
+import (
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/snapp-incubator/go-symspell/pkg/items"
+	verbositypkg "github.com/snapp-incubator/go-symspell/pkg/verbosity"
+)
+
+// WordSegmentation divides a string into words by inserting missing spaces at the appropriate positions;
+// misspelled words are corrected and do not affect segmentation, existing spaces are allowed and considered
+// for optimum segmentation.
+func (s *SymSpell) WordSegmentation(phrase string, maxEditDistance int, maxSegmentationWordLength int) items.Composition {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	runes := []rune(strings.ReplaceAll(s.normalize(phrase), "-", ""))
+	if len(runes) == 0 || maxSegmentationWordLength < 1 {
+		return items.Composition{}
+	}
+	// Compositions are kept in a circular array, one slot per possible word length
+	arraySize := min(maxSegmentationWordLength, len(runes))
+	compositions := make([]items.Composition, arraySize)
+	circularIndex := -1
+
+	for j := range runes {
+		imax := min(len(runes)-j, maxSegmentationWordLength)
+		for i := 1; i <= imax; i++ {
+			part, separatorLength, topEd := s.segmentationPart(runes[j : j+i])
+			topResult, topDistance, topProbabilityLog := s.segmentationWord(part, maxEditDistance)
+			topEd += topDistance
+
+			destinationIndex := (i + circularIndex) % arraySize
+			if j == 0 {
+				compositions[destinationIndex] = items.Composition{
+					SegmentedString:   part,
+					CorrectedString:   topResult,
+					DistanceSum:       topEd,
+					ProbabilityLogSum: topProbabilityLog,
+				}
+				continue
+			}
+			current := compositions[circularIndex]
+			destination := compositions[destinationIndex]
+			distanceSum := current.DistanceSum + separatorLength + topEd
+			if i == maxSegmentationWordLength ||
+				((current.DistanceSum+topEd == destination.DistanceSum || distanceSum == destination.DistanceSum) &&
+					destination.ProbabilityLogSum < current.ProbabilityLogSum+topProbabilityLog) ||
+				distanceSum < destination.DistanceSum {
+				compositions[destinationIndex] = items.Composition{
+					SegmentedString:   current.SegmentedString + " " + part,
+					CorrectedString:   current.CorrectedString + " " + topResult,
+					DistanceSum:       distanceSum,
+					ProbabilityLogSum: current.ProbabilityLogSum + topProbabilityLog,
+				}
+			}
+		}
+		circularIndex++
+		if circularIndex == arraySize {
+			circularIndex = 0
+		}
+	}
+	return compositions[circularIndex]
+}
+
+// segmentationPart strips a leading space and the inner spaces of a part, returning the cleaned part,
+// the length of the separator that must be inserted before it and the edit distance of the removed spaces.
+func (s *SymSpell) segmentationPart(runes []rune) (string, int, int) {
+	separatorLength := 1
+	if unicode.IsSpace(runes[0]) {
+		// Existing space is reused, so no separator has to be inserted
+		runes = runes[1:]
+		separatorLength = 0
+	}
+	part := strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, string(runes))
+	return part, separatorLength, len(runes) - len([]rune(part))
+}
+
+// segmentationWord corrects a single part and returns its correction, distance and log10 probability.
+func (s *SymSpell) segmentationWord(part string, maxEditDistance int) (string, int, float64) {
+	partLen := len([]rune(part))
+	// Numbers and ignored tokens are never corrected, "۱۲" must not become "۲"
+	if s.ignored(part) || strings.IndexFunc(part, unicode.IsDigit) >= 0 {
+		maxEditDistance = 0
+	}
+	results, _ := s.lookup(part, verbositypkg.Top, maxEditDistance)
+	if len(results) > 0 {
+		return results[0].Term, results[0].Distance, math.Log10(float64(results[0].Count) / s.N)
+	}
+	// Unknown word: estimate the probability from its length, longer words are less probable
+	return part, partLen, math.Log10(10.0 / (s.N * math.Pow(10.0, float64(partLen))))
+}
@@ -0,0 +1,77 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/snapp-incubator/go-symspell/pkg/options"
+)
+
+func TestWordSegmentation(t *testing.T) {
+	symSpell, err := NewSymSpell(options.WithCountThreshold(1), options.WithMaxDictionaryEditDistance(1))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	symSpell.createDictionaryEntry("the", 1000)
+	symSpell.createDictionaryEntry("quick", 300)
+	symSpell.createDictionaryEntry("brown", 200)
+	symSpell.createDictionaryEntry("fox", 100)
+	symSpell.createDictionaryEntry("2", 500)
+
+	tests := []struct {
+		name      string
+		input     string
+		segmented string
+		corrected string
+		distance  int
+	}{
+		{
+			name:      "missing spaces",
+			input:     "thequickbrownfox",
+			segmented: "the quick brown fox",
+			corrected: "the quick brown fox",
+			distance:  3,
+		},
+		{
+			name:      "existing spaces",
+			input:     "the quickbrown fox",
+			segmented: "the quick brown fox",
+			corrected: "the quick brown fox",
+			distance:  1,
+		},
+		{
+			name:      "misspelled word",
+			input:     "thequikbrownfox",
+			segmented: "the quik brown fox",
+			corrected: "the quick brown fox",
+			distance:  4,
+		},
+		{
+			name:      "numbers are not corrected",
+			input:     "thefox12",
+			segmented: "the fox 12",
+			corrected: "the fox 12",
+			distance:  4,
+		},
+		{
+			name:      "empty",
+			input:     "",
+			segmented: "",
+			corrected: "",
+			distance:  0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := symSpell.WordSegmentation(tt.input, 1, 10)
+			if result.SegmentedString != tt.segmented {
+				t.Errorf("Expected segmented '%s', got '%s'", tt.segmented, result.SegmentedString)
+			}
+			if result.CorrectedString != tt.corrected {
+				t.Errorf("Expected corrected '%s', got '%s'", tt.corrected, result.CorrectedString)
+			}
+			if result.DistanceSum != tt.distance {
+				t.Errorf("Expected distance %d, got %d", tt.distance, result.DistanceSum)
+			}
+		})
+	}
+}
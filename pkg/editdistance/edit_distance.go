@@ -4,12 +4,29 @@ type IEditDistance interface {
 	Distance(a, b string) int
 }
 
+// IBoundedEditDistance is implemented by edit distances that can stop early once a maximum is exceeded.
+type IBoundedEditDistance interface {
+	IEditDistance
+	// DistanceMax returns the distance between a and b, or -1 when it is greater than maxDistance.
+	DistanceMax(a, b string, maxDistance int) int
+}
+
 func NewEditDistance(Type string) *EditDistance {
 	return &EditDistance{Type: Type}
 }
 
 const (
+	// DamerauLevenshtein is the optimal string alignment variant, a substring is never edited more than once.
 	DamerauLevenshtein = "DamerauLevenshtein"
+	// OptimalStringAlignment is an alias of DamerauLevenshtein.
+	OptimalStringAlignment = "OptimalStringAlignment"
+	// Levenshtein counts insertions, deletions and substitutions.
+	Levenshtein = "Levenshtein"
+	// TrueDamerauLevenshtein also allows edits between transposed characters.
+	TrueDamerauLevenshtein = "TrueDamerauLevenshtein"
+	// JaroWinkler ranks by similarity, scaled to a distance in [0, max(len(a), len(b))] and rounded up so that
+	// only equal strings have a distance of 0.
+	JaroWinkler = "JaroWinkler"
 )
 
 type EditDistance struct {
@@ -18,65 +35,126 @@ type EditDistance struct {
 
 func (d EditDistance) Distance(a, b string) int {
 	switch d.Type {
-	case DamerauLevenshtein:
+	case DamerauLevenshtein, OptimalStringAlignment:
 		return damerauLevenshteinDistance(a, b)
+	case Levenshtein:
+		return levenshteinDistance(a, b)
+	case TrueDamerauLevenshtein:
+		return trueDamerauLevenshteinDistance([]rune(a), []rune(b))
+	case JaroWinkler:
+		return jaroWinklerDistance(a, b)
+	}
+	return 0
+}
+
+func (d EditDistance) DistanceMax(a, b string, maxDistance int) int {
+	switch d.Type {
+	case DamerauLevenshtein, OptimalStringAlignment:
+		return damerauLevenshteinDistanceMax([]rune(a), []rune(b), maxDistance)
+	case Levenshtein:
+		return levenshteinDistanceMax([]rune(a), []rune(b), maxDistance)
+	case TrueDamerauLevenshtein:
+		return boundDistance(trueDamerauLevenshteinDistance([]rune(a), []rune(b)), maxDistance)
+	case JaroWinkler:
+		return boundDistance(jaroWinklerDistance(a, b), maxDistance)
 	}
 	return 0
 }
 
 func damerauLevenshteinDistance(a, b string) int {
-	m := len(a)
-	n := len(b)
+	ra, rb := []rune(a), []rune(b)
+	return damerauLevenshteinDistanceMax(ra, rb, max(len(ra), len(rb)))
+}
 
-	// If either string is empty, the distance is the length of the other string.
+// damerauLevenshteinDistanceMax computes the optimal string alignment distance of a and b on runes,
+// it returns -1 as soon as the distance is known to be greater than maxDistance.
+func damerauLevenshteinDistanceMax(a, b []rune, maxDistance int) int {
+	a, b = trimCommonAffixes(a, b)
+	// Make a the shorter string
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	m, n := len(a), len(b)
 	if m == 0 {
-		return n
+		return boundDistance(n, maxDistance)
 	}
-	if n == 0 {
-		return m
+	if n-m > maxDistance {
+		return -1
 	}
+	maxDistance = min(maxDistance, n)
 
-	// Create a distance matrix (m+1)x(n+1)
-	distance := make([][]int, m+1)
-	for i := range distance {
-		distance[i] = make([]int, n+1)
+	// Only two rows of the matrix are kept: costs holds the current row and prevCosts the row before
+	costs := make([]int, n)
+	prevCosts := make([]int, n)
+	for j := range n {
+		costs[j] = min(j+1, maxDistance+1)
+	}
+	lenDiff := n - m
+	// Only cells within maxDistance of the diagonals can be lower than maxDistance
+	jStartOffset := maxDistance - lenDiff
+	jStart, jEnd := 0, maxDistance
+	current := 0
+	var aRune rune
+	for i := range m {
+		prevARune := aRune
+		aRune = a[i]
+		left := i
+		current = left + 1
+		nextTransCost := 0
+		if i > jStartOffset {
+			jStart++
+		}
+		if jEnd < n {
+			jEnd++
+		}
+		var bRune rune
+		if jStart > 0 {
+			// The cell before the window is known to be greater than maxDistance
+			bRune = b[jStart-1]
+			left = costs[jStart-1]
+			current = maxDistance + 1
+			nextTransCost = prevCosts[jStart-1]
+		}
+		for j := jStart; j < jEnd; j++ {
+			above := current
+			thisTransCost := nextTransCost
+			nextTransCost = prevCosts[j]
+			// Diagonal cost of the substitution
+			current = left
+			prevCosts[j] = left
+			left = costs[j]
+			prevBRune := bRune
+			bRune = b[j]
+			if aRune != bRune {
+				current = min(current, left, above) + 1
+				if i != 0 && j != 0 && aRune == prevBRune && prevARune == bRune {
+					current = min(current, thisTransCost+1)
+				}
+			}
+			costs[j] = current
+		}
+		if costs[i+lenDiff] > maxDistance {
+			return -1
+		}
 	}
+	return boundDistance(current, maxDistance)
+}
 
-	// Initialize the distance matrix
-	for i := 0; i <= m; i++ {
-		distance[i][0] = i
+// trimCommonAffixes removes the prefix and suffix shared by a and b, they do not change the distance.
+func trimCommonAffixes(a, b []rune) ([]rune, []rune) {
+	for len(a) > 0 && len(b) > 0 && a[len(a)-1] == b[len(b)-1] {
+		a, b = a[:len(a)-1], b[:len(b)-1]
 	}
-	for j := 0; j <= n; j++ {
-		distance[0][j] = j
+	start := 0
+	for start < len(a) && start < len(b) && a[start] == b[start] {
+		start++
 	}
+	return a[start:], b[start:]
+}
 
-	// Fill the distance matrix
-	for i := 1; i <= m; i++ {
-		for j := 1; j <= n; j++ {
-			cost := 0
-			if a[i-1] != b[j-1] {
-				cost = 1
-			}
-
-			// Calculate distances for insertion, deletion, and substitution
-			distance[i][j] = min(
-				distance[i-1][j]+1, // Deletion
-				min(
-					distance[i][j-1]+1,      // Insertion
-					distance[i-1][j-1]+cost, // Substitution
-				),
-			)
-
-			// Check for transposition
-			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
-				distance[i][j] = min(
-					distance[i][j],
-					distance[i-2][j-2]+cost, // Transposition
-				)
-			}
-		}
+func boundDistance(distance, maxDistance int) int {
+	if distance > maxDistance {
+		return -1
 	}
-
-	// Return the bottom-right value, which is the Damerau-Levenshtein distance
-	return distance[m][n]
+	return distance
 }
@@ -1,6 +1,7 @@
 package editdistance
 
 import (
+	"math"
 	"testing"
 )
 
@@ -44,7 +45,7 @@ func TestDamerauLevenshteinDistance(t *testing.T) {
 				a: "میدان",
 				b: "میذات",
 			},
-			want: 3,
+			want: 2,
 		},
 		{
 			name: "Test DamerauLevenshteinDistance",
@@ -52,7 +53,7 @@ func TestDamerauLevenshteinDistance(t *testing.T) {
 				a: "کناب",
 				b: "کتاب",
 			},
-			want: 2,
+			want: 1,
 		},
 		{
 			name: "Test DamerauLevenshteinDistance",
@@ -79,3 +80,174 @@ func TestDamerauLevenshteinDistance(t *testing.T) {
 		})
 	}
 }
+
+func TestDamerauLevenshteinDistanceMax(t *testing.T) {
+	tests := []struct {
+		name        string
+		a           string
+		b           string
+		maxDistance int
+		want        int
+	}{
+		{name: "within max", a: "kitten", b: "sitting", maxDistance: 3, want: 3},
+		{name: "exceeds max", a: "kitten", b: "sitting", maxDistance: 2, want: -1},
+		{name: "length difference exceeds max", a: "ab", b: "abcdef", maxDistance: 3, want: -1},
+		{name: "equal", a: "خیابان", b: "خیابان", maxDistance: 0, want: 0},
+		{name: "persian substitution", a: "حیابان", b: "خیابان", maxDistance: 1, want: 1},
+		{name: "persian transposition", a: "خیبااان", b: "خیابان", maxDistance: 2, want: 2},
+		{name: "common prefix and suffix", a: "ملاصدزا", b: "ملاصدرا", maxDistance: 1, want: 1},
+		{name: "empty", a: "", b: "میدان", maxDistance: 5, want: 5},
+		{name: "empty exceeds max", a: "میدان", b: "", maxDistance: 4, want: -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewEditDistance(DamerauLevenshtein).DistanceMax(tt.a, tt.b, tt.maxDistance); got != tt.want {
+				t.Errorf("DistanceMax() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEditDistanceAlgorithms(t *testing.T) {
+	tests := []struct {
+		name      string
+		algorithm string
+		a         string
+		b         string
+		want      int
+	}{
+		{name: "levenshtein", algorithm: Levenshtein, a: "kitten", b: "sitting", want: 3},
+		{name: "levenshtein transposition", algorithm: Levenshtein, a: "میدان", b: "میدنا", want: 2},
+		{name: "levenshtein persian", algorithm: Levenshtein, a: "حیابان", b: "خیابان", want: 1},
+		{name: "optimal string alignment", algorithm: OptimalStringAlignment, a: "میدان", b: "میدنا", want: 1},
+		{name: "optimal string alignment restricted", algorithm: OptimalStringAlignment, a: "ca", b: "abc", want: 3},
+		{name: "true damerau levenshtein", algorithm: TrueDamerauLevenshtein, a: "ca", b: "abc", want: 2},
+		{name: "true damerau levenshtein persian", algorithm: TrueDamerauLevenshtein, a: "خیبان", b: "خیابان", want: 1},
+		{name: "jaro winkler equal", algorithm: JaroWinkler, a: "خیابان", b: "خیابان", want: 0},
+		{name: "jaro winkler", algorithm: JaroWinkler, a: "martha", b: "marhta", want: 1},
+		{name: "jaro winkler different", algorithm: JaroWinkler, a: "abc", b: "xyz", want: 3},
+		{name: "jaro winkler unequal is never 0", algorithm: JaroWinkler, a: "خیابان", b: "خیابانن", want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewEditDistance(tt.algorithm).Distance(tt.a, tt.b); got != tt.want {
+				t.Errorf("Distance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJaroWinklerSimilarity(t *testing.T) {
+	tests := []struct {
+		a    string
+		b    string
+		want float64
+	}{
+		{a: "martha", b: "marhta", want: 0.961},
+		{a: "dixon", b: "dicksonx", want: 0.813},
+		{a: "", b: "", want: 1},
+		{a: "abc", b: "", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			if got := JaroWinklerSimilarity(tt.a, tt.b); math.Abs(got-tt.want) > 0.001 {
+				t.Errorf("JaroWinklerSimilarity() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWeightedDistance(t *testing.T) {
+	custom := NewWeighted().SetSubstitutionCost('a', 'e', 0.5).SetInsertionCost('s', 0.25).SetDeletionCost('x', 2)
+	custom.TranspositionCost = 0.5
+	tests := []struct {
+		name     string
+		weighted *Weighted
+		a        string
+		b        string
+		want     float64
+	}{
+		{name: "default costs", weighted: NewWeighted(), a: "kitten", b: "sitting", want: 3},
+		{name: "persian homophone", weighted: NewPersianWeighted(), a: "ضره", b: "ذره", want: PersianConfusionCost},
+		{name: "persian other letter", weighted: NewPersianWeighted(), a: "ضره", b: "دره", want: 1},
+		{name: "persian two homophones", weighted: NewPersianWeighted(), a: "اصفحان", b: "اسفهان", want: 2 * PersianConfusionCost},
+		{name: "substitution", weighted: custom, a: "steam", b: "steem", want: 0.5},
+		{name: "insertion", weighted: custom, a: "steam", b: "steams", want: 0.25},
+		{name: "deletion", weighted: custom, a: "steamx", b: "steam", want: 2},
+		{name: "transposition", weighted: custom, a: "steam", b: "setam", want: 0.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.weighted.WeightedDistance(tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("WeightedDistance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeyboardLayoutAdjacent(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout *KeyboardLayout
+		a      rune
+		b      rune
+		want   bool
+	}{
+		{name: "same row", layout: QWERTY, a: 'a', b: 's', want: true},
+		{name: "row above", layout: QWERTY, a: 's', b: 'w', want: true},
+		{name: "row above other side", layout: QWERTY, a: 's', b: 'e', want: true},
+		{name: "row below", layout: QWERTY, a: 's', b: 'x', want: true},
+		{name: "shifted", layout: QWERTY, a: 'S', b: 'd', want: true},
+		{name: "far", layout: QWERTY, a: 'a', b: 'd', want: false},
+		{name: "same key", layout: QWERTY, a: 'a', b: 'A', want: false},
+		{name: "persian same row", layout: PersianISIRI9147, a: 'ن', b: 'م', want: true},
+		{name: "persian row above", layout: PersianISIRI9147, a: 'س', b: 'ص', want: true},
+		{name: "persian shifted", layout: PersianISIRI9147, a: 'آ', b: 'ل', want: true},
+		{name: "persian far", layout: PersianISIRI9147, a: 'ب', b: 'ن', want: false},
+		{name: "unknown", layout: PersianISIRI9147, a: 'a', b: 's', want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.layout.Adjacent(tt.a, tt.b); got != tt.want {
+				t.Errorf("Adjacent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeyboardWeighted(t *testing.T) {
+	weighted := NewKeyboardWeighted(QWERTY, PersianISIRI9147)
+	if got := weighted.WeightedDistance("cst", "cat"); got != KeyboardAdjacentCost {
+		t.Errorf("WeightedDistance() = %v, want %v", got, KeyboardAdjacentCost)
+	}
+	if got := weighted.WeightedDistance("cst", "cut"); got != 1 {
+		t.Errorf("WeightedDistance() = %v, want %v", got, 1)
+	}
+	if got := weighted.WeightedDistance("میدام", "میدان"); got != KeyboardAdjacentCost {
+		t.Errorf("WeightedDistance() = %v, want %v", got, KeyboardAdjacentCost)
+	}
+}
+
+func TestSwapLayout(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		from *KeyboardLayout
+		to   *KeyboardLayout
+		want string
+		ok   bool
+	}{
+		{name: "english to persian", text: "odhfhk", from: QWERTY, to: PersianISIRI9147, want: "خیابان", ok: true},
+		{name: "shifted", text: "Hchnd", from: QWERTY, to: PersianISIRI9147, want: "آزادی", ok: true},
+		{name: "persian to english", text: "سفقثثف", from: PersianISIRI9147, to: QWERTY, want: "street", ok: true},
+		{name: "unknown rune", text: "خیابان", from: QWERTY, to: PersianISIRI9147, ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := SwapLayout(tt.text, tt.from, tt.to)
+			if ok != tt.ok || got != tt.want {
+				t.Errorf("SwapLayout() = %v, %v, want %v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package editdistance
+
+import "math"
+
+const (
+	// jaroWinklerPrefixScale is how much the score is adjusted upwards for a common prefix.
+	jaroWinklerPrefixScale = 0.1
+	// jaroWinklerMaxPrefix is the maximum length of the common prefix taken into account.
+	jaroWinklerMaxPrefix = 4
+)
+
+// JaroWinklerSimilarity returns the Jaro-Winkler similarity of a and b on runes,
+// from 0 for no similarity to 1 for equal strings.
+func JaroWinklerSimilarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	jaro := jaroSimilarity(ra, rb)
+
+	prefix := 0
+	for prefix < min(len(ra), len(rb), jaroWinklerMaxPrefix) && ra[prefix] == rb[prefix] {
+		prefix++
+	}
+	return jaro + float64(prefix)*jaroWinklerPrefixScale*(1-jaro)
+}
+
+func jaroSimilarity(a, b []rune) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	// Runes only match when they are not farther than half of the longer string
+	matchDistance := max(max(len(a), len(b))/2-1, 0)
+	aMatches := make([]bool, len(a))
+	bMatches := make([]bool, len(b))
+	matches := 0
+	for i := range a {
+		start := max(0, i-matchDistance)
+		end := min(i+matchDistance+1, len(b))
+		for j := start; j < end; j++ {
+			if !bMatches[j] && a[i] == b[j] {
+				aMatches[i], bMatches[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	// Count matching runes which are out of order
+	transpositions := 0
+	j := 0
+	for i := range a {
+		if !aMatches[i] {
+			continue
+		}
+		for !bMatches[j] {
+			j++
+		}
+		if a[i] != b[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	return (m/float64(len(a)) + m/float64(len(b)) + (m-float64(transpositions)/2)/m) / 3
+}
+
+// JaroWinklerWeighted ranks suggestions by their Jaro-Winkler similarity, its weighted distance is the
+// dissimilarity scaled to the length of the longer string without rounding.
+type JaroWinklerWeighted struct{}
+
+// WeightedDistance returns the scaled dissimilarity of a and b.
+func (JaroWinklerWeighted) WeightedDistance(a, b string) float64 {
+	return jaroWinklerScaled(a, b)
+}
+
+// jaroWinklerDistance rounds the scaled dissimilarity of a and b up, so that it can be bounded by a max edit
+// distance and only equal strings have a distance of 0.
+func jaroWinklerDistance(a, b string) int {
+	if a == b {
+		return 0
+	}
+	// The tolerance keeps a whole number from being rounded up by a floating point error
+	return max(int(math.Ceil(jaroWinklerScaled(a, b)-1e-9)), 1)
+}
+
+// jaroWinklerScaled scales the dissimilarity of a and b to the length of the longer string.
+func jaroWinklerScaled(a, b string) float64 {
+	length := max(len([]rune(a)), len([]rune(b)))
+	return (1 - JaroWinklerSimilarity(a, b)) * float64(length)
+}
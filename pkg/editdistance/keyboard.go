@@ -0,0 +1,149 @@
+package editdistance
+
+import (
+	"math"
+	"strings"
+)
+
+// KeyboardLayout describes the key positions of a keyboard layout.
+type KeyboardLayout struct {
+	Name string
+	// Rows holds the unshifted runes of each row of keys, from the number row to the bottom row.
+	Rows []string
+	// RowOffsets holds the horizontal offset of each row in key widths.
+	RowOffsets []float64
+	// Shifted maps runes typed with shift to the unshifted rune of the same key.
+	Shifted map[rune]rune
+
+	keys      map[rune]Key
+	positions map[Key]rune
+}
+
+// Key is the position of a key in a KeyboardLayout.
+type Key struct {
+	Row    int
+	Column int
+	Shift  bool
+}
+
+// NewKeyboardLayout creates a layout from its rows, row offsets and shifted runes.
+func NewKeyboardLayout(name string, rows []string, rowOffsets []float64, shifted map[rune]rune) *KeyboardLayout {
+	layout := &KeyboardLayout{
+		Name:       name,
+		Rows:       rows,
+		RowOffsets: rowOffsets,
+		Shifted:    shifted,
+		keys:       make(map[rune]Key),
+		positions:  make(map[Key]rune),
+	}
+	for row, runes := range rows {
+		for column, r := range []rune(runes) {
+			key := Key{Row: row, Column: column}
+			layout.keys[r] = key
+			layout.positions[key] = r
+		}
+	}
+	for shiftedRune, r := range shifted {
+		if key, found := layout.keys[r]; found {
+			key.Shift = true
+			layout.keys[shiftedRune] = key
+			layout.positions[key] = shiftedRune
+		}
+	}
+	return layout
+}
+
+// Key returns the position of r in the layout.
+func (l *KeyboardLayout) Key(r rune) (Key, bool) {
+	key, found := l.keys[r]
+	return key, found
+}
+
+// Rune returns the rune typed by a key of the layout.
+func (l *KeyboardLayout) Rune(key Key) (rune, bool) {
+	r, found := l.positions[key]
+	return r, found
+}
+
+// Adjacent reports whether a and b are typed with neighbouring keys, including keys of the rows above and below.
+func (l *KeyboardLayout) Adjacent(a, b rune) bool {
+	keyA, foundA := l.keys[a]
+	keyB, foundB := l.keys[b]
+	if !foundA || !foundB || (keyA.Row == keyB.Row && keyA.Column == keyB.Column) {
+		return false
+	}
+	dx := math.Abs(l.x(keyA) - l.x(keyB))
+	switch math.Abs(float64(keyA.Row - keyB.Row)) {
+	case 0:
+		return dx == 1
+	case 1:
+		return dx < 1
+	}
+	return false
+}
+
+// SwapLayout maps text typed with the from layout active to the runes of the same keys on the to layout,
+// such as "odhfhk" from QWERTY to "خیابان" on PersianISIRI9147. It returns false when a rune is not on from.
+func SwapLayout(text string, from, to *KeyboardLayout) (string, bool) {
+	var swapped strings.Builder
+	for _, r := range text {
+		key, found := from.Key(r)
+		if !found {
+			return "", false
+		}
+		if r, found = to.Rune(key); !found {
+			return "", false
+		}
+		swapped.WriteRune(r)
+	}
+	return swapped.String(), true
+}
+
+func (l *KeyboardLayout) x(key Key) float64 {
+	return float64(key.Column) + l.RowOffsets[key.Row]
+}
+
+// keyboardRowOffsets are the offsets of the number, top, home and bottom rows of a standard keyboard.
+var keyboardRowOffsets = []float64{0, 1.5, 1.75, 2.25}
+
+// QWERTY is the English QWERTY layout.
+var QWERTY = NewKeyboardLayout("QWERTY",
+	[]string{"`1234567890-=", "qwertyuiop[]\\", "asdfghjkl;'", "zxcvbnm,./"},
+	keyboardRowOffsets,
+	shiftedRunes("~!@#$%^&*()_+QWERTYUIOP{}|ASDFGHJKL:\"ZXCVBNM<>?", "`1234567890-=qwertyuiop[]\\asdfghjkl;'zxcvbnm,./"),
+)
+
+// PersianISIRI9147 is the Persian standard layout defined by ISIRI 9147.
+var PersianISIRI9147 = NewKeyboardLayout("ISIRI 9147",
+	[]string{"\u200d۱۲۳۴۵۶۷۸۹۰-=", "ضصثقفغعهخحجچ\\", "شسیبلاتنمکگ", "ظطزرذدپو./"},
+	keyboardRowOffsets,
+	shiftedRunes("آژء؟", "ازپ/"),
+)
+
+func shiftedRunes(shifted, unshifted string) map[rune]rune {
+	runes := make(map[rune]rune)
+	unshiftedRunes := []rune(unshifted)
+	for i, r := range []rune(shifted) {
+		runes[r] = unshiftedRunes[i]
+	}
+	return runes
+}
+
+// KeyboardAdjacentCost is the substitution cost of neighbouring keys in NewKeyboardWeighted.
+const KeyboardAdjacentCost = 0.5
+
+// NewKeyboardWeighted creates a Weighted distance where substituting runes of neighbouring keys of any of the
+// layouts costs KeyboardAdjacentCost.
+func NewKeyboardWeighted(layouts ...*KeyboardLayout) *Weighted {
+	w := NewWeighted()
+	for _, layout := range layouts {
+		for a := range layout.keys {
+			for b := range layout.keys {
+				if layout.Adjacent(a, b) {
+					w.Substitutions[[2]rune{a, b}] = KeyboardAdjacentCost
+				}
+			}
+		}
+	}
+	return w
+}
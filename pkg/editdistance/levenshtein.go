@@ -0,0 +1,46 @@
+package editdistance
+
+func levenshteinDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	return levenshteinDistanceMax(ra, rb, max(len(ra), len(rb)))
+}
+
+// levenshteinDistanceMax computes the Levenshtein distance of a and b on runes,
+// it returns -1 as soon as every cell of a row is greater than maxDistance.
+func levenshteinDistanceMax(a, b []rune, maxDistance int) int {
+	a, b = trimCommonAffixes(a, b)
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	m, n := len(a), len(b)
+	if m == 0 {
+		return boundDistance(n, maxDistance)
+	}
+	if n-m > maxDistance {
+		return -1
+	}
+
+	costs := make([]int, n+1)
+	for j := range costs {
+		costs[j] = j
+	}
+	for i := range m {
+		diagonal := costs[0]
+		costs[0] = i + 1
+		rowMin := costs[0]
+		for j := 1; j <= n; j++ {
+			above := costs[j]
+			if a[i] == b[j-1] {
+				costs[j] = diagonal
+			} else {
+				costs[j] = min(diagonal, above, costs[j-1]) + 1
+			}
+			diagonal = above
+			rowMin = min(rowMin, costs[j])
+		}
+		if rowMin > maxDistance {
+			return -1
+		}
+	}
+	return boundDistance(costs[n], maxDistance)
+}
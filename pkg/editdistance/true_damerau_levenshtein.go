@@ -0,0 +1,50 @@
+package editdistance
+
+// trueDamerauLevenshteinDistance computes the unrestricted Damerau-Levenshtein distance of a and b,
+// unlike the optimal string alignment it allows to edit transposed characters again ("ca" to "abc" is 2).
+func trueDamerauLevenshteinDistance(a, b []rune) int {
+	a, b = trimCommonAffixes(a, b)
+	m, n := len(a), len(b)
+	if m == 0 || n == 0 {
+		return max(m, n)
+	}
+
+	// The matrix has an extra first row and column holding the maximum distance
+	maxDistance := m + n
+	distance := make([][]int, m+2)
+	for i := range distance {
+		distance[i] = make([]int, n+2)
+	}
+	distance[0][0] = maxDistance
+	for i := 0; i <= m; i++ {
+		distance[i+1][0] = maxDistance
+		distance[i+1][1] = i
+	}
+	for j := 0; j <= n; j++ {
+		distance[0][j+1] = maxDistance
+		distance[1][j+1] = j
+	}
+
+	// lastRow holds the last row of a where each rune has been seen
+	lastRow := make(map[rune]int)
+	for i := 1; i <= m; i++ {
+		lastColumn := 0
+		for j := 1; j <= n; j++ {
+			k := lastRow[b[j-1]]
+			l := lastColumn
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+				lastColumn = j
+			}
+			distance[i+1][j+1] = min(
+				distance[i][j]+cost,              // Substitution
+				distance[i+1][j]+1,               // Insertion
+				distance[i][j+1]+1,               // Deletion
+				distance[k][l]+(i-k-1)+1+(j-l-1), // Transposition
+			)
+		}
+		lastRow[a[i-1]] = i
+	}
+	return distance[m+1][n+1]
+}
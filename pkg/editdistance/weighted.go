@@ -0,0 +1,144 @@
+package editdistance
+
+import "math"
+
+// IWeightedEditDistance is implemented by edit distances with real-valued costs.
+type IWeightedEditDistance interface {
+	WeightedDistance(a, b string) float64
+}
+
+// Weighted is an optimal string alignment distance with configurable costs. Substitutions, insertions and
+// deletions of specific runes override the default costs.
+type Weighted struct {
+	InsertionCost     float64
+	DeletionCost      float64
+	SubstitutionCost  float64
+	TranspositionCost float64
+	Substitutions     map[[2]rune]float64
+	Insertions        map[rune]float64
+	Deletions         map[rune]float64
+}
+
+// NewWeighted creates a Weighted distance where every edit costs 1.
+func NewWeighted() *Weighted {
+	return &Weighted{
+		InsertionCost:     1,
+		DeletionCost:      1,
+		SubstitutionCost:  1,
+		TranspositionCost: 1,
+		Substitutions:     make(map[[2]rune]float64),
+		Insertions:        make(map[rune]float64),
+		Deletions:         make(map[rune]float64),
+	}
+}
+
+// SetSubstitutionCost sets the cost of substituting a with b and b with a.
+func (w *Weighted) SetSubstitutionCost(a, b rune, cost float64) *Weighted {
+	w.Substitutions[[2]rune{a, b}] = cost
+	w.Substitutions[[2]rune{b, a}] = cost
+	return w
+}
+
+// SetSubstitutionGroup sets the cost of substituting any two runes of group with each other.
+func (w *Weighted) SetSubstitutionGroup(cost float64, group ...rune) *Weighted {
+	for i, a := range group {
+		for _, b := range group[i+1:] {
+			w.SetSubstitutionCost(a, b, cost)
+		}
+	}
+	return w
+}
+
+// SetInsertionCost sets the cost of inserting r.
+func (w *Weighted) SetInsertionCost(r rune, cost float64) *Weighted {
+	w.Insertions[r] = cost
+	return w
+}
+
+// SetDeletionCost sets the cost of deleting r.
+func (w *Weighted) SetDeletionCost(r rune, cost float64) *Weighted {
+	w.Deletions[r] = cost
+	return w
+}
+
+// PersianConfusionCost is the substitution cost of Persian homophone letters in NewPersianWeighted.
+const PersianConfusionCost = 0.3
+
+// PersianConfusionGroups are the Persian letters sharing the same sound.
+var PersianConfusionGroups = [][]rune{
+	{'ز', 'ذ', 'ض', 'ظ'},
+	{'س', 'ص', 'ث'},
+	{'ت', 'ط'},
+	{'ه', 'ح'},
+	{'ق', 'غ'},
+}
+
+// NewPersianWeighted creates a Weighted distance where substituting Persian homophone letters costs
+// PersianConfusionCost.
+func NewPersianWeighted() *Weighted {
+	w := NewWeighted()
+	for _, group := range PersianConfusionGroups {
+		w.SetSubstitutionGroup(PersianConfusionCost, group...)
+	}
+	return w
+}
+
+// Distance rounds the weighted distance, so that Weighted can also be used as an IEditDistance.
+func (w *Weighted) Distance(a, b string) int {
+	return int(math.Round(w.WeightedDistance(a, b)))
+}
+
+// WeightedDistance computes the optimal string alignment distance of a and b on runes with the configured costs.
+func (w *Weighted) WeightedDistance(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	m, n := len(ra), len(rb)
+
+	distance := make([][]float64, m+1)
+	for i := range distance {
+		distance[i] = make([]float64, n+1)
+	}
+	for i := 1; i <= m; i++ {
+		distance[i][0] = distance[i-1][0] + w.deletionCost(ra[i-1])
+	}
+	for j := 1; j <= n; j++ {
+		distance[0][j] = distance[0][j-1] + w.insertionCost(rb[j-1])
+	}
+
+	for i := 1; i <= m; i++ {
+		for j := 1; j <= n; j++ {
+			distance[i][j] = min(
+				distance[i-1][j]+w.deletionCost(ra[i-1]),                // Deletion
+				distance[i][j-1]+w.insertionCost(rb[j-1]),               // Insertion
+				distance[i-1][j-1]+w.substitutionCost(ra[i-1], rb[j-1]), // Substitution
+			)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] && ra[i-1] != rb[j-1] {
+				distance[i][j] = min(distance[i][j], distance[i-2][j-2]+w.TranspositionCost) // Transposition
+			}
+		}
+	}
+	return distance[m][n]
+}
+
+func (w *Weighted) substitutionCost(a, b rune) float64 {
+	if a == b {
+		return 0
+	}
+	if cost, found := w.Substitutions[[2]rune{a, b}]; found {
+		return cost
+	}
+	return w.SubstitutionCost
+}
+
+func (w *Weighted) insertionCost(r rune) float64 {
+	if cost, found := w.Insertions[r]; found {
+		return cost
+	}
+	return w.InsertionCost
+}
+
+func (w *Weighted) deletionCost(r rune) float64 {
+	if cost, found := w.Deletions[r]; found {
+		return cost
+	}
+	return w.DeletionCost
+}
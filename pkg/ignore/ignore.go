@@ -0,0 +1,54 @@
+package ignore
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// Numbers matches integers and decimals written with digits of any script, such as 12, ۱۲ or ١٢٫٥.
+	Numbers = regexp.MustCompile(`^[+-]?\p{Nd}+(?:[.,/:٫٬]\p{Nd}+)*$`)
+	// URLs matches web addresses with a scheme or starting with www.
+	URLs = regexp.MustCompile(`^(?i:(?:https?|ftp)://\S+|www\.\S+\.\S+)$`)
+	// Emails matches email addresses.
+	Emails = regexp.MustCompile(`^[\p{L}\p{Nd}._%+-]+@[\p{L}\p{Nd}-]+(?:\.[\p{L}\p{Nd}-]+)*\.\p{L}{2,}$`)
+	// Phones matches phone numbers with an optional country code and dashes, such as +98-912-123-4567 or (021)88776655.
+	Phones = regexp.MustCompile(`^(?:\+|00)?(?:\(\p{Nd}{1,4}\)-?)?\p{Nd}{2,}(?:-\p{Nd}{2,})*$`)
+	// PostalCodes matches ten digit Iranian postal codes, optionally written as two groups of five digits.
+	PostalCodes = regexp.MustCompile(`^\p{Nd}{5}-?\p{Nd}{5}$`)
+)
+
+// Rules detects tokens that must not be corrected, such as numbers, URLs or product codes.
+type Rules struct {
+	patterns []*regexp.Regexp
+}
+
+// New creates rules from patterns, a token is ignored when a pattern matches it. Patterns should be anchored
+// with ^ and $ to match whole tokens.
+func New(patterns ...*regexp.Regexp) *Rules {
+	return &Rules{patterns: patterns}
+}
+
+// Default creates rules with the built-in detectors of numbers, URLs, emails, phone numbers and postal codes,
+// followed by patterns.
+func Default(patterns ...*regexp.Regexp) *Rules {
+	return New(append([]*regexp.Regexp{Numbers, URLs, Emails, Phones, PostalCodes}, patterns...)...)
+}
+
+// Match reports whether a token is ignored.
+func (r *Rules) Match(token string) bool {
+	for _, pattern := range r.patterns {
+		if pattern.MatchString(token) {
+			return true
+		}
+	}
+	return false
+}
+
+// punctuation is trimmed around the chunks of a phrase before they are matched.
+const punctuation = `.,;:!?()[]{}"'«»،؛؟`
+
+// TrimPunctuation removes the punctuation around a chunk of a phrase, such as the comma following a URL.
+func TrimPunctuation(chunk string) string {
+	return strings.Trim(chunk, punctuation)
+}
@@ -0,0 +1,55 @@
+package ignore
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestDefault(t *testing.T) {
+	tests := []struct {
+		token string
+		want  bool
+	}{
+		{token: "12", want: true},
+		{token: "۱۲", want: true},
+		{token: "١٢٫٥", want: true},
+		{token: "3.14", want: true},
+		{token: "https://snapp.ir/ride?id=1", want: true},
+		{token: "www.snapp.ir", want: true},
+		{token: "support@snapp.ir", want: true},
+		{token: "+98-912-123-4567", want: true},
+		{token: "(021)88776655", want: true},
+		{token: "09121234567", want: true},
+		{token: "12345-67890", want: true},
+		{token: "خیابان", want: false},
+		{token: "street", want: false},
+		{token: "15خرداد", want: false},
+		{token: "snapp.ir", want: false},
+	}
+	rules := Default()
+	for _, test := range tests {
+		if got := rules.Match(test.token); got != test.want {
+			t.Errorf("Match(%q) = %v, want %v", test.token, got, test.want)
+		}
+	}
+}
+
+func TestUserPatterns(t *testing.T) {
+	productCodes := regexp.MustCompile(`^[A-Z]{2,4}-\d+$`)
+	rules := New(productCodes)
+	if !rules.Match("SKU-1234") {
+		t.Errorf("Expected the product code to be ignored")
+	}
+	if rules.Match("12") {
+		t.Errorf("Expected numbers not to be ignored without the built-in rules")
+	}
+	if rules := Default(productCodes); !rules.Match("SKU-1234") || !rules.Match("12") {
+		t.Errorf("Expected the built-in rules to be followed by the user patterns")
+	}
+}
+
+func TestTrimPunctuation(t *testing.T) {
+	if got := TrimPunctuation("«www.snapp.ir»،"); got != "www.snapp.ir" {
+		t.Errorf("Expected %q, got %q", "www.snapp.ir", got)
+	}
+}
@@ -0,0 +1,50 @@
+package items
+
+// Operation is the change a compound lookup made to a span of input terms.
+type Operation int
+
+const (
+	// Keep leaves the term unchanged.
+	Keep Operation = iota
+	// Replace corrects the term to another term.
+	Replace
+	// Split corrects the term to several terms.
+	Split
+	// Merge combines adjacent terms into one term.
+	Merge
+	// ExactTransform replaces the term by its exact transform, such as an abbreviation by its expansion.
+	ExactTransform
+)
+
+// String returns the name of the operation.
+func (o Operation) String() string {
+	switch o {
+	case Keep:
+		return "keep"
+	case Replace:
+		return "replace"
+	case Split:
+		return "split"
+	case Merge:
+		return "merge"
+	case ExactTransform:
+		return "exact-transform"
+	}
+	return "unknown"
+}
+
+// Alignment maps a span of the input terms of a compound lookup to the terms that replaced them.
+type Alignment struct {
+	Operation Operation
+	// Start and End are the indexes of the first input term and of the term after the last, in the order the
+	// phrase was split into terms.
+	Start int
+	End   int
+	// Input holds the input terms of the span, lower cased unless the case is preserved.
+	Input []string
+	// Output holds the terms the span was corrected to.
+	Output []string
+	// Distance and Count are the edit distance and count of the output, the distance of a kept term is 0.
+	Distance int
+	Count    int
+}
@@ -0,0 +1,13 @@
+package items
+
+// Composition represents the result of a word segmentation.
+type Composition struct {
+	// SegmentedString is the input split into words, without corrections.
+	SegmentedString string
+	// CorrectedString is the segmented input with each word corrected.
+	CorrectedString string
+	// DistanceSum is the sum of edit distances, including inserted spaces.
+	DistanceSum int
+	// ProbabilityLogSum is the sum of the log10 word probabilities.
+	ProbabilityLogSum float64
+}
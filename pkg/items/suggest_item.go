@@ -5,4 +5,30 @@ type SuggestItem struct {
 	Term     string
 	Distance int
 	Count    int
+	// WeightedDistance is the real-valued distance, it is set when a weighted edit distance is configured.
+	WeightedDistance float64
+	// LayoutSwapped is set when the term matches the input typed with the wrong keyboard layout.
+	LayoutSwapped bool
+	// SkeletonMatched is set when the term shares its dotless skeleton with the input, WeightedDistance holds the
+	// reduced cost of the differing dots and Distance their number, which can exceed the max edit distance.
+	SkeletonMatched bool
+	// PhoneticMatched is set when the term was found by its phonetic key, its distance can exceed the max edit distance.
+	PhoneticMatched bool
+	// Transliterated is set when Latin-script words of the input were transliterated to Persian script.
+	Transliterated bool
+	// Collapsed is set when runs of repeated letters of the input were shortened to find the term, Distance counts
+	// the removed letters and can exceed the max edit distance.
+	Collapsed bool
+	// Ignored is set when the input matched an ignore rule and was passed through unchanged.
+	Ignored bool
+	// Stem, Prefix and Suffix are set when the term was corrected by stripping affixes, Term is the corrected stem
+	// with the affixes attached again.
+	Stem   string
+	Prefix string
+	Suffix string
+	// Alignment is set by compound lookups, it maps every span of input terms to the terms that replaced them.
+	Alignment []Alignment
+	// Score is the joint score of an alternative of LookupCompoundTopK, the log10 probability of its terms given
+	// the previous term less a penalty per edit, or the context score of a suggestion of LookupInContext.
+	Score float64
 }
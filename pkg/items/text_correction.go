@@ -0,0 +1,21 @@
+package items
+
+// TextCorrection represents the result of a full-text correction.
+type TextCorrection struct {
+	// Text is the input with its words corrected, everything between the words is kept as it is.
+	Text string
+	// Edits lists the corrected words in the order of the input.
+	Edits []Edit
+}
+
+// Edit is the correction of a word of the input. Start and End are byte offsets into the input, RuneStart and
+// RuneEnd are rune offsets.
+type Edit struct {
+	Original   string
+	Correction string
+	Start      int
+	End        int
+	RuneStart  int
+	RuneEnd    int
+	Distance   int
+}
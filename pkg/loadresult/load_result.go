@@ -0,0 +1,72 @@
+package loadresult
+
+// Kind identifies the type of dictionary that was loaded.
+type Kind string
+
+const (
+	// Unigram is a dictionary of terms and their frequency.
+	Unigram Kind = "unigram"
+	// Bigram is a dictionary of term pairs and their frequency.
+	Bigram Kind = "bigram"
+	// Exact is a dictionary of terms and their exact replacement.
+	Exact Kind = "exact"
+)
+
+// SkipReason describes why a dictionary line was skipped.
+type SkipReason string
+
+const (
+	// EmptyLine is reported for blank lines.
+	EmptyLine SkipReason = "empty line"
+	// MissingFields is reported when a line has fewer fields than the term and count indexes require.
+	MissingFields SkipReason = "missing fields"
+	// InvalidCount is reported when the count field is not an integer.
+	InvalidCount SkipReason = "invalid count"
+)
+
+// Dictionary reports the outcome of loading a single dictionary.
+type Dictionary struct {
+	Kind     Kind
+	Path     string
+	Loaded   bool
+	Accepted int
+	Skipped  int
+	Reasons  map[SkipReason]int
+	Err      error
+}
+
+// NewDictionary creates an empty report for a dictionary of the given kind.
+func NewDictionary(kind Kind, path string) Dictionary {
+	return Dictionary{Kind: kind, Path: path, Reasons: make(map[SkipReason]int)}
+}
+
+// Accept records a line that has been added to the dictionary.
+func (d *Dictionary) Accept() {
+	d.Accepted++
+}
+
+// Skip records a line that has been ignored and the reason for it.
+func (d *Dictionary) Skip(reason SkipReason) {
+	d.Skipped++
+	d.Reasons[reason]++
+}
+
+// Result reports the outcome of loading all dictionaries of a SymSpell instance.
+type Result struct {
+	Dictionaries []Dictionary
+}
+
+// Add appends the report of a dictionary to the result.
+func (r *Result) Add(dictionary Dictionary) {
+	r.Dictionaries = append(r.Dictionaries, dictionary)
+}
+
+// Loaded reports whether a dictionary of the given kind has been loaded successfully.
+func (r Result) Loaded(kind Kind) bool {
+	for _, dictionary := range r.Dictionaries {
+		if dictionary.Kind == kind && dictionary.Loaded {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package morphology
+
+import "strings"
+
+// zwnj is the zero width non-joiner Persian affixes are usually attached with.
+const zwnj = "\u200c"
+
+// AffixTable lists the prefixes and suffixes that can be stripped from a word to find its stem.
+type AffixTable struct {
+	Prefixes []string
+	Suffixes []string
+	// Joiners are written between an affix and the stem, an affix is only stripped when followed or preceded by one.
+	Joiners []string
+	// MinStemLength is the minimum number of runes left as stem after stripping affixes.
+	MinStemLength int
+}
+
+// Analysis is a word split into an optional prefix, a stem and an optional suffix.
+type Analysis struct {
+	Prefix       string
+	PrefixJoiner string
+	Stem         string
+	SuffixJoiner string
+	Suffix       string
+}
+
+// NewAffixTable creates an affix table whose affixes are attached with a ZWNJ or written together with the stem.
+func NewAffixTable(prefixes, suffixes []string) *AffixTable {
+	return &AffixTable{
+		Prefixes:      prefixes,
+		Suffixes:      suffixes,
+		Joiners:       []string{zwnj, ""},
+		MinStemLength: 2,
+	}
+}
+
+// Persian is the built-in table of common Persian verb prefixes, plural, possessive, comparative and object markers.
+var Persian = NewAffixTable(
+	[]string{"نمی", "می"},
+	[]string{"هایی", "های", "ها", "ترین", "تر", "ای", "ی", "را", "ام", "ات", "اش", "مان", "تان", "شان"},
+)
+
+// Analyze returns every way word can be split into affixes of the table and a stem, analyses without any affix
+// are not returned.
+func (t *AffixTable) Analyze(word string) []Analysis {
+	prefixes := []Analysis{{Stem: word}}
+	for _, prefix := range t.Prefixes {
+		for _, joiner := range t.Joiners {
+			if stem, found := strings.CutPrefix(word, prefix+joiner); found {
+				prefixes = append(prefixes, Analysis{Prefix: prefix, PrefixJoiner: joiner, Stem: stem})
+			}
+		}
+	}
+
+	var analyses []Analysis
+	for _, analysis := range prefixes {
+		if analysis.Prefix != "" && t.validStem(analysis.Stem) {
+			analyses = append(analyses, analysis)
+		}
+		for _, suffix := range t.Suffixes {
+			for _, joiner := range t.Joiners {
+				if stem, found := strings.CutSuffix(analysis.Stem, joiner+suffix); found && t.validStem(stem) {
+					suffixed := analysis
+					suffixed.Stem, suffixed.SuffixJoiner, suffixed.Suffix = stem, joiner, suffix
+					analyses = append(analyses, suffixed)
+				}
+			}
+		}
+	}
+	return analyses
+}
+
+// validStem reports whether a stem is long enough and does not end with a joiner left by another affix.
+func (t *AffixTable) validStem(stem string) bool {
+	if strings.HasPrefix(stem, zwnj) || strings.HasSuffix(stem, zwnj) {
+		return false
+	}
+	return len([]rune(stem)) >= t.MinStemLength
+}
+
+// Attach writes the affixes of the analysis around stem with the joiners they were found with.
+func (a Analysis) Attach(stem string) string {
+	var word strings.Builder
+	word.WriteString(a.Prefix)
+	word.WriteString(a.PrefixJoiner)
+	word.WriteString(stem)
+	word.WriteString(a.SuffixJoiner)
+	word.WriteString(a.Suffix)
+	return word.String()
+}
@@ -0,0 +1,52 @@
+package morphology
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestAnalyze(t *testing.T) {
+	tests := []struct {
+		word string
+		want []Analysis
+	}{
+		{
+			word: "کتاب\u200cها",
+			want: []Analysis{{Stem: "کتاب", SuffixJoiner: "\u200c", Suffix: "ها"}},
+		},
+		{
+			word: "می\u200cروم",
+			want: []Analysis{{Prefix: "می", PrefixJoiner: "\u200c", Stem: "روم"}},
+		},
+		{
+			word: "کتابهای",
+			want: []Analysis{
+				{Stem: "کتاب", Suffix: "های"},
+				{Stem: "کتابه", Suffix: "ای"},
+				{Stem: "کتابها", Suffix: "ی"},
+			},
+		},
+		{
+			word: "میروی",
+			want: []Analysis{
+				{Stem: "میرو", Suffix: "ی"},
+				{Prefix: "می", Stem: "روی"},
+				{Prefix: "می", Stem: "رو", Suffix: "ی"},
+			},
+		},
+		{word: "ها", want: nil},
+	}
+	for _, test := range tests {
+		got := Persian.Analyze(test.word)
+		if !slices.Equal(got, test.want) {
+			t.Errorf("For word '%s', expected %v, got %v", test.word, test.want, got)
+		}
+	}
+}
+
+func TestAttach(t *testing.T) {
+	analysis := Analysis{Prefix: "می", PrefixJoiner: "\u200c", Stem: "رو", Suffix: "ی"}
+	if got := analysis.Attach("روم"); got != "می\u200cرومی" {
+		t.Errorf("Expected %q, got %q", "می\u200cرومی", got)
+	}
+}
@@ -0,0 +1,79 @@
+package normalizer
+
+import "golang.org/x/text/unicode/norm"
+
+// Normalizer rewrites text to a canonical form, it is applied to dictionary entries and queries alike.
+type Normalizer interface {
+	Normalize(text string) string
+}
+
+// Func adapts a function to the Normalizer interface.
+type Func func(text string) string
+
+func (f Func) Normalize(text string) string {
+	return f(text)
+}
+
+// Chain applies normalizers in order.
+func Chain(normalizers ...Normalizer) Normalizer {
+	return Func(func(text string) string {
+		for _, normalizer := range normalizers {
+			text = normalizer.Normalize(text)
+		}
+		return text
+	})
+}
+
+var (
+	// NFC composes characters by canonical equivalence.
+	NFC Normalizer = Func(norm.NFC.String)
+	// NFKC composes characters by compatibility equivalence, Arabic presentation forms become plain letters.
+	NFKC Normalizer = Func(norm.NFKC.String)
+)
+
+const (
+	zwnj    = '\u200c'
+	tatweel = '\u0640'
+)
+
+// persianRunes maps Arabic letters, Arabic-Indic and Persian digits and zero-width variants to their canonical form.
+var persianRunes = map[rune]rune{
+	'ي': 'ی', 'ى': 'ی', 'ك': 'ک', 'ھ': 'ه', 'ە': 'ه',
+	'٠': '0', '١': '1', '٢': '2', '٣': '3', '٤': '4', '٥': '5', '٦': '6', '٧': '7', '٨': '8', '٩': '9',
+	'۰': '0', '۱': '1', '۲': '2', '۳': '3', '۴': '4', '۵': '5', '۶': '6', '۷': '7', '۸': '8', '۹': '9',
+	'\u200b': zwnj, '\u00ad': zwnj, '\ufeff': zwnj,
+}
+
+// Persian unifies the Arabic and Persian forms of ی, ک and ه, removes tashkil and tatweel, maps Arabic-Indic and
+// Persian digits to ASCII digits and replaces zero-width variants with a single ZWNJ. A ZWNJ next to a space or
+// at either end of the text is dropped.
+func Persian() Normalizer {
+	return Func(func(text string) string {
+		normalized := make([]rune, 0, len(text))
+		for _, r := range text {
+			if mapped, found := persianRunes[r]; found {
+				r = mapped
+			}
+			if isTashkil(r) || r == tatweel {
+				continue
+			}
+			last := len(normalized) - 1
+			switch {
+			case r == zwnj && (last < 0 || normalized[last] == zwnj || normalized[last] == ' '):
+				continue
+			case r == ' ' && last >= 0 && normalized[last] == zwnj:
+				normalized = normalized[:last]
+			}
+			normalized = append(normalized, r)
+		}
+		if last := len(normalized) - 1; last >= 0 && normalized[last] == zwnj {
+			normalized = normalized[:last]
+		}
+		return string(normalized)
+	})
+}
+
+// isTashkil reports whether r is an Arabic diacritic.
+func isTashkil(r rune) bool {
+	return (r >= '\u064b' && r <= '\u065f') || r == '\u0670' || (r >= '\u0610' && r <= '\u061a')
+}
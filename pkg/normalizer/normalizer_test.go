@@ -0,0 +1,45 @@
+package normalizer
+
+import "testing"
+
+func TestPersian(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "arabic yeh and kaf", text: "كتابي", want: "کتابی"},
+		{name: "alef maksura", text: "موسى", want: "موسی"},
+		{name: "tashkil", text: "كِتَابٌ", want: "کتاب"},
+		{name: "tatweel", text: "کتـــاب", want: "کتاب"},
+		{name: "digits", text: "۱۲۳ ٤٥٦", want: "123 456"},
+		{name: "zero width space", text: "می\u200bروم", want: "می\u200cروم"},
+		{name: "repeated zwnj", text: "می\u200c\u200cروم", want: "می\u200cروم"},
+		{name: "zwnj next to spaces", text: "\u200cکتاب \u200cها \u200c", want: "کتاب ها "},
+		{name: "trailing zwnj", text: "کتاب\u200c", want: "کتاب"},
+		{name: "unchanged", text: "خیابان آزادی", want: "خیابان آزادی"},
+	}
+	persian := Persian()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := persian.Normalize(test.text); got != test.want {
+				t.Errorf("Expected %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestChain(t *testing.T) {
+	// NFKC maps the presentation forms of lam-alef and heh to plain letters before the Persian normalizer runs
+	chain := Chain(NFKC, Persian())
+	if got := chain.Normalize("ﻻ ﮫ ي"); got != "لا ه ی" {
+		t.Errorf("Expected %q, got %q", "لا ه ی", got)
+	}
+	// NFC composes alef with madda above
+	if got := NFC.Normalize("ا\u0653"); got != "آ" {
+		t.Errorf("Expected %q, got %q", "آ", got)
+	}
+	if got := Chain().Normalize("كتاب"); got != "كتاب" {
+		t.Errorf("Expected an empty chain to keep the text, got %q", got)
+	}
+}
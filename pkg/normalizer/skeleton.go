@@ -0,0 +1,43 @@
+package normalizer
+
+import "unicode"
+
+// skeletonRunes maps letters that only differ by their dots to a dotless letter.
+var skeletonRunes = map[rune]rune{
+	'ب': 'ٮ', 'پ': 'ٮ', 'ت': 'ٮ', 'ث': 'ٮ', 'ٮ': 'ٮ',
+	'ج': 'ح', 'چ': 'ح', 'ح': 'ح', 'خ': 'ح',
+	'د': 'د', 'ذ': 'د',
+	'ر': 'ر', 'ز': 'ر', 'ژ': 'ر',
+	'س': 'س', 'ش': 'س',
+	'ص': 'ص', 'ض': 'ص',
+	'ط': 'ط', 'ظ': 'ط',
+	'ع': 'ع', 'غ': 'ع',
+	'ه': 'ه', 'ة': 'ه',
+	'ک': 'ک', 'ك': 'ک',
+}
+
+// skeletonJoinedRunes maps letters whose shape depends on whether they are joined to the next letter, to their
+// joined and final dotless letters. Joined ن and ی look like ٮ, joined ق looks like ف.
+var skeletonJoinedRunes = map[rune][2]rune{
+	'ن': {'ٮ', 'ں'}, 'ں': {'ٮ', 'ں'},
+	'ی': {'ٮ', 'ى'}, 'ي': {'ٮ', 'ى'}, 'ى': {'ٮ', 'ى'}, 'ئ': {'ٮ', 'ى'},
+	'ف': {'ڡ', 'ڡ'}, 'ڡ': {'ڡ', 'ڡ'},
+	'ق': {'ڡ', 'ٯ'}, 'ٯ': {'ڡ', 'ٯ'},
+}
+
+// Skeleton returns the dotless skeleton (rasm) of text, words whose letters only differ by dots, such as the
+// joined forms of ب, پ, ت, ث, ن and ی, share a skeleton. Every rune is mapped to a single rune.
+func Skeleton(text string) string {
+	runes := []rune(text)
+	for i, r := range runes {
+		if dotless, found := skeletonRunes[r]; found {
+			runes[i] = dotless
+		} else if forms, found := skeletonJoinedRunes[r]; found {
+			runes[i] = forms[1]
+			if i+1 < len(runes) && unicode.IsLetter(runes[i+1]) && unicode.Is(unicode.Arabic, runes[i+1]) {
+				runes[i] = forms[0]
+			}
+		}
+	}
+	return string(runes)
+}
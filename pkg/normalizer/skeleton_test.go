@@ -0,0 +1,29 @@
+package normalizer
+
+import "testing"
+
+func TestSkeleton(t *testing.T) {
+	tests := []struct {
+		a, b  string
+		equal bool
+	}{
+		{a: "تبریز", b: "نیریز", equal: true},
+		{a: "بار", b: "نار", equal: true},
+		{a: "پیر", b: "تیر", equal: true},
+		{a: "خط", b: "حظ", equal: true},
+		{a: "فرق", b: "قرف", equal: false},
+		{a: "قند", b: "فند", equal: true},
+		{a: "بین", b: "بیت", equal: false},
+		{a: "ٮٮرٮر", b: "تبریز", equal: true},
+		{a: "کار", b: "بار", equal: false},
+	}
+	for _, test := range tests {
+		if equal := Skeleton(test.a) == Skeleton(test.b); equal != test.equal {
+			t.Errorf("Expected skeletons of '%s' and '%s' equal %v, got '%s' and '%s'",
+				test.a, test.b, test.equal, Skeleton(test.a), Skeleton(test.b))
+		}
+	}
+	if got := Skeleton("street 12"); got != "street 12" {
+		t.Errorf("Expected text without Arabic letters to be unchanged, got '%s'", got)
+	}
+}
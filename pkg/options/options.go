@@ -1,5 +1,13 @@
 package options
 
+import (
+	"github.com/snapp-incubator/go-symspell/pkg/editdistance"
+	"github.com/snapp-incubator/go-symspell/pkg/ignore"
+	"github.com/snapp-incubator/go-symspell/pkg/morphology"
+	"github.com/snapp-incubator/go-symspell/pkg/normalizer"
+	"github.com/snapp-incubator/go-symspell/pkg/transliteration"
+)
+
 var DefaultOptions = SymspellOptions{
 	MaxDictionaryEditDistance: 2,
 	PrefixLength:              7,
@@ -9,6 +17,7 @@ var DefaultOptions = SymspellOptions{
 	SplitWordBySpace:          false,
 	SplitWordAndNumber:        false,
 	MinimumCharacterToChange:  1,
+	EditDistance:              editdistance.NewEditDistance(editdistance.DamerauLevenshtein),
 }
 
 type SymspellOptions struct {
@@ -20,6 +29,18 @@ type SymspellOptions struct {
 	SplitWordBySpace          bool
 	SplitWordAndNumber        bool
 	MinimumCharacterToChange  int
+	EditDistance              editdistance.IEditDistance
+	WeightedEditDistance      editdistance.IWeightedEditDistance
+	KeyboardLayouts           []*editdistance.KeyboardLayout
+	LayoutSwaps               []*editdistance.KeyboardLayout
+	Normalizer                normalizer.Normalizer
+	Affixes                   *morphology.AffixTable
+	SkeletonCost              float64
+	PhoneticIndex             bool
+	Transliterator            *transliteration.Transliterator
+	CollapseRepeats           bool
+	IgnoreRules               *ignore.Rules
+	RealWordRatio             float64
 }
 
 type Options interface {
@@ -85,3 +106,106 @@ func WithSplitWordAndNumbers() Options {
 		options.SplitWordAndNumber = true
 	})
 }
+
+// WithEditDistance sets the algorithm used to compare suggestions, such as
+// editdistance.NewEditDistance(editdistance.Levenshtein) or a custom implementation.
+func WithEditDistance(editDistance editdistance.IEditDistance) Options {
+	return NewFuncOption(func(options *SymspellOptions) {
+		options.EditDistance = editDistance
+	})
+}
+
+// WithWeightedEditDistance ranks suggestions by a real-valued distance, such as editdistance.NewPersianWeighted(),
+// instead of the integer distance.
+func WithWeightedEditDistance(weightedEditDistance editdistance.IWeightedEditDistance) Options {
+	return NewFuncOption(func(options *SymspellOptions) {
+		options.WeightedEditDistance = weightedEditDistance
+	})
+}
+
+// WithKeyboardLayouts breaks ties between suggestions at the same distance by preferring typos of neighbouring keys
+// of the layouts, such as editdistance.QWERTY and editdistance.PersianISIRI9147, before falling back to the count.
+func WithKeyboardLayouts(layouts ...*editdistance.KeyboardLayout) Options {
+	return NewFuncOption(func(options *SymspellOptions) {
+		options.KeyboardLayouts = layouts
+	})
+}
+
+// WithLayoutSwap also looks up words typed with the wrong layout active, by mapping them to the keys of the other
+// layouts. It defaults to editdistance.QWERTY and editdistance.PersianISIRI9147 when no layout is given.
+func WithLayoutSwap(layouts ...*editdistance.KeyboardLayout) Options {
+	return NewFuncOption(func(options *SymspellOptions) {
+		if len(layouts) == 0 {
+			layouts = []*editdistance.KeyboardLayout{editdistance.QWERTY, editdistance.PersianISIRI9147}
+		}
+		options.LayoutSwaps = layouts
+	})
+}
+
+// WithNormalizer normalizes dictionary terms, bigrams, exact transforms and queries, such as
+// normalizer.Chain(normalizer.NFKC, normalizer.Persian()), so that variant spellings of a word are looked up alike.
+func WithNormalizer(textNormalizer normalizer.Normalizer) Options {
+	return NewFuncOption(func(options *SymspellOptions) {
+		options.Normalizer = textNormalizer
+	})
+}
+
+// WithAffixes corrects words that are not in the dictionary by stripping the affixes of the table, such as
+// morphology.Persian, correcting the stem and attaching the affixes again.
+func WithAffixes(affixes *morphology.AffixTable) Options {
+	return NewFuncOption(func(options *SymspellOptions) {
+		options.Affixes = affixes
+	})
+}
+
+// WithSkeletonIndex indexes the dotless skeleton of every word, so that words whose letters only differ by dots, such
+// as ب, پ, ت, ث, ن and ی, are suggested at cost per differing letter instead of a full substitution.
+func WithSkeletonIndex(cost float64) Options {
+	return NewFuncOption(func(options *SymspellOptions) {
+		options.SkeletonCost = cost
+	})
+}
+
+// WithPhoneticIndex indexes the phonetic key of every word, so that words sounding like the input, such as "خیابان"
+// for "khiaban", are suggested when no word is found within the max edit distance.
+func WithPhoneticIndex() Options {
+	return NewFuncOption(func(options *SymspellOptions) {
+		options.PhoneticIndex = true
+	})
+}
+
+// WithTransliterator transliterates the Latin-script words of LookupCompound phrases that are missing from the
+// dictionary, such as "khiaban azadi" written with transliteration.NewFinglish(), to Persian script.
+func WithTransliterator(transliterator *transliteration.Transliterator) Options {
+	return NewFuncOption(func(options *SymspellOptions) {
+		options.Transliterator = transliterator
+	})
+}
+
+// WithRepeatCollapse shortens runs of repeated letters, as in the chat-style "سلاااام", to one or two repeats and
+// looks the shortened forms up in the dictionary before searching by edit distance. It is not applied to lookups
+// with a max edit distance of 0, and with verbosity All the shortened forms are listed along with the words found by
+// edit distance.
+func WithRepeatCollapse() Options {
+	return NewFuncOption(func(options *SymspellOptions) {
+		options.CollapseRepeats = true
+	})
+}
+
+// WithIgnoreRules passes the tokens matched by the rules, such as the numbers, URLs, emails, phone numbers and postal
+// codes of ignore.Default(), through Lookup and LookupCompound unchanged.
+func WithIgnoreRules(rules *ignore.Rules) Options {
+	return NewFuncOption(func(options *SymspellOptions) {
+		options.IgnoreRules = rules
+	})
+}
+
+// WithRealWordErrors corrects words of LookupCompound phrases that are in the dictionary but unlikely in their
+// context, such as a misspelled street name that is another street's name. A word is replaced by a close word that
+// forms a bigram with its neighbours when the context probability of the replacement is at least ratio times
+// higher.
+func WithRealWordErrors(ratio float64) Options {
+	return NewFuncOption(func(options *SymspellOptions) {
+		options.RealWordRatio = ratio
+	})
+}
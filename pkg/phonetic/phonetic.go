@@ -0,0 +1,82 @@
+package phonetic
+
+import "strings"
+
+// letterKeys maps Persian and Latin letters to the consonant they are pronounced as, letters pronounced alike share
+// a key. Vowels, alef, ain and hamza have no key since Persian script usually omits short vowels.
+var letterKeys = map[rune]string{
+	'ب': "B", 'پ': "P", 'ت': "T", 'ط': "T", 'ث': "S", 'س': "S", 'ص': "S",
+	'ج': "J", 'چ': "C", 'ح': "H", 'ه': "H", 'ة': "H", 'خ': "X",
+	'د': "D", 'ذ': "Z", 'ز': "Z", 'ض': "Z", 'ظ': "Z", 'ر': "R", 'ژ': "Ž",
+	'ش': "Š", 'غ': "Q", 'ق': "Q", 'ف': "F", 'ک': "K", 'ك': "K", 'گ': "G",
+	'ل': "L", 'م': "M", 'ن': "N", 'و': "V", 'ی': "Y", 'ي': "Y", 'ى': "Y",
+	'b': "B", 'p': "P", 't': "T", 's': "S", 'j': "J", 'h': "H", 'x': "X",
+	'd': "D", 'z': "Z", 'r': "R", 'f': "F", 'q': "Q", 'k': "K", 'c': "K",
+	'g': "G", 'l': "L", 'm': "M", 'n': "N", 'v': "V", 'w': "V", 'y': "Y",
+}
+
+// latinDigraphs maps Finglish letter pairs written for a single Persian consonant.
+var latinDigraphs = map[string]string{
+	"kh": "X", "sh": "Š", "ch": "C", "zh": "Ž", "gh": "Q", "ph": "F",
+}
+
+// semivowels are consonants at the start of a word and long vowels elsewhere.
+var semivowels = map[rune]bool{'و': true, 'ی': true, 'ي': true, 'ى': true, 'v': true, 'w': true, 'y': true}
+
+// Key returns the phonetic key of Persian or Finglish text, in the spirit of Soundex: words that sound alike, such
+// as "خیابان", "khiaban" and "khiyaban", or "ثواب" and "صواب", share a key. Words of the text are keyed separately
+// and joined by a space.
+func Key(text string) string {
+	var keys []string
+	for _, word := range strings.Fields(strings.ToLower(text)) {
+		if key := wordKey([]rune(word)); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return strings.Join(keys, " ")
+}
+
+func wordKey(word []rune) string {
+	var key strings.Builder
+	last := ""
+	for i := 0; i < len(word); i++ {
+		r := word[i]
+		code := letterKeys[r]
+		if i+1 < len(word) {
+			if digraph, found := latinDigraphs[string(word[i:i+2])]; found {
+				code = digraph
+				i++
+			}
+		}
+		switch {
+		case semivowels[r] && i > 0:
+			// و and ی are read as long vowels after the first letter
+			code = ""
+		case i == len(word)-1 && i > 0 && isSilentH(r, word[i-1]):
+			code = ""
+		}
+		if code == "" {
+			// A vowel between two consonants keeps them apart, "بابا" keys as "BB"
+			last = ""
+			continue
+		}
+		// Doubled consonants are written once in Persian script
+		if code == last {
+			continue
+		}
+		key.WriteString(code)
+		last = code
+	}
+	return key.String()
+}
+
+// isSilentH reports whether a final h is silent, as the ه after a consonant in "خانه" or the h of "khaneh".
+func isSilentH(r, previous rune) bool {
+	switch r {
+	case 'ه':
+		return letterKeys[previous] != "" && !semivowels[previous]
+	case 'h':
+		return previous == 'e'
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+package phonetic
+
+import "testing"
+
+func TestKey(t *testing.T) {
+	tests := []struct {
+		a, b  string
+		equal bool
+	}{
+		{a: "خیابان", b: "khiaban", equal: true},
+		{a: "خیابان", b: "khiyaban", equal: true},
+		{a: "آزادی", b: "azadi", equal: true},
+		{a: "تهران", b: "Tehran", equal: true},
+		{a: "صبح", b: "sobh", equal: true},
+		{a: "محمد", b: "mohammad", equal: true},
+		{a: "خانه", b: "khane", equal: true},
+		{a: "خانه", b: "khaneh", equal: true},
+		{a: "شاه", b: "shah", equal: true},
+		{a: "قاسم", b: "ghasem", equal: true},
+		{a: "قاسم", b: "غاسم", equal: true},
+		{a: "ثواب", b: "صواب", equal: true},
+		{a: "ولیعصر", b: "valiasr", equal: true},
+		{a: "خیابان آزادی", b: "khiaban azadi", equal: true},
+		{a: "شاه", b: "شاد", equal: false},
+		{a: "تهران", b: "تبریز", equal: false},
+		// Repeated consonants only collapse when they are adjacent
+		{a: "بابا", b: "با", equal: false},
+		{a: "مامان", b: "مان", equal: false},
+		{a: "بابا", b: "baba", equal: true},
+	}
+	for _, test := range tests {
+		if equal := Key(test.a) == Key(test.b); equal != test.equal {
+			t.Errorf("Expected keys of '%s' and '%s' equal %v, got '%s' and '%s'",
+				test.a, test.b, test.equal, Key(test.a), Key(test.b))
+		}
+	}
+	if got := Key("خیابان آزادی"); got != "XBN ZD" {
+		t.Errorf("Expected 'XBN ZD', got '%s'", got)
+	}
+	if got := Key("بابا"); got != "BB" {
+		t.Errorf("Expected 'BB', got '%s'", got)
+	}
+}
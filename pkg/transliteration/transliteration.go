@@ -0,0 +1,163 @@
+package transliteration
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+//go:embed finglish.txt
+var finglishRules string
+
+// DefaultMaxCandidates is the default number of candidates generated for a word.
+const DefaultMaxCandidates = 1024
+
+// Transliterator generates Persian-script candidates for Latin-script (Finglish) words from transliteration pairs.
+type Transliterator struct {
+	// MaxCandidates bounds the candidates generated for a word, long words have many spellings.
+	MaxCandidates int
+	rules         map[string][]rule
+	maxLatin      int
+}
+
+// rule replaces a Latin sequence with a Persian one, optionally only at the start or the end of a word.
+type rule struct {
+	persian string
+	initial bool
+	final   bool
+}
+
+// New creates a Transliterator without any pair.
+func New() *Transliterator {
+	return &Transliterator{MaxCandidates: DefaultMaxCandidates, rules: make(map[string][]rule)}
+}
+
+// NewFinglish creates a Transliterator with the built-in Finglish pairs.
+func NewFinglish() *Transliterator {
+	t := New()
+	if err := t.LoadRules(strings.NewReader(finglishRules)); err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// LoadRules reads transliteration pairs, one per line: a Latin sequence and its Persian replacement separated by
+// whitespace. A line holding only the Latin sequence maps it to nothing, as short vowels are usually not written.
+// A ^ prefix restricts the pair to the start of a word and a $ suffix to its end, both make a whole-word pair such
+// as "^tehran$ تهران" that replaces the spellings built from shorter pairs. Empty lines and lines starting with #
+// are skipped. Loaded pairs are preferred over the pairs already known when candidates are equally frequent.
+func (t *Transliterator) LoadRules(r io.Reader) error {
+	loaded := make(map[string][]rule)
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) > 2 {
+			return fmt.Errorf("line %d: expected a Latin sequence and a Persian replacement, got %q", lineNumber, line)
+		}
+		latin := strings.ToLower(fields[0])
+		var pair rule
+		latin, pair.initial = strings.CutPrefix(latin, "^")
+		latin, pair.final = strings.CutSuffix(latin, "$")
+		if latin == "" {
+			return fmt.Errorf("line %d: empty Latin sequence", lineNumber)
+		}
+		if len(fields) == 2 {
+			pair.persian = fields[1]
+		}
+		loaded[latin] = append(loaded[latin], pair)
+		t.maxLatin = max(t.maxLatin, len([]rune(latin)))
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	for latin, pairs := range loaded {
+		t.rules[latin] = append(pairs, t.rules[latin]...)
+	}
+	return nil
+}
+
+// Candidates returns the Persian spellings of a Latin word in order of preference, at most MaxCandidates of them.
+func (t *Transliterator) Candidates(word string) []string {
+	word = strings.ToLower(word)
+	runes := []rune(word)
+	if len(runes) == 0 {
+		return nil
+	}
+	// A whole-word pair replaces the spellings built from shorter pairs
+	var wholeWords []string
+	for _, pair := range t.rules[word] {
+		if pair.initial && pair.final {
+			wholeWords = append(wholeWords, pair.persian)
+		}
+	}
+	if len(wholeWords) > 0 {
+		return wholeWords
+	}
+	// partial[i] holds the spellings of the first i runes
+	partial := make([][]string, len(runes)+1)
+	seen := make([]map[string]bool, len(runes)+1)
+	partial[0] = []string{""}
+	for i := range runes {
+		if len(partial[i]) == 0 {
+			continue
+		}
+		for length := 1; length <= t.maxLatin && i+length <= len(runes); length++ {
+			end := i + length
+			replacements := t.replacements(string(runes[i:end]), i == 0, end == len(runes))
+			if len(replacements) > 0 && seen[end] == nil {
+				seen[end] = make(map[string]bool)
+			}
+			for _, prefix := range partial[i] {
+				for _, persian := range replacements {
+					candidate := prefix + persian
+					if len(partial[end]) >= t.MaxCandidates || seen[end][candidate] {
+						continue
+					}
+					seen[end][candidate] = true
+					partial[end] = append(partial[end], candidate)
+				}
+			}
+		}
+	}
+	return partial[len(runes)]
+}
+
+// replacements returns the Persian replacements of a Latin sequence at a position, pairs restricted to the
+// position take precedence over the general ones.
+func (t *Transliterator) replacements(latin string, initial, final bool) []string {
+	var specific, general []string
+	for _, pair := range t.rules[latin] {
+		switch {
+		case (pair.initial && !initial) || (pair.final && !final):
+		case pair.initial || pair.final:
+			specific = append(specific, pair.persian)
+		default:
+			general = append(general, pair.persian)
+		}
+	}
+	if len(specific) > 0 {
+		return specific
+	}
+	return general
+}
+
+// IsLatin reports whether word only holds Latin letters and apostrophes, and at least one letter.
+func IsLatin(word string) bool {
+	letters := 0
+	for _, r := range word {
+		switch {
+		case unicode.Is(unicode.Latin, r):
+			letters++
+		case r != '\'':
+			return false
+		}
+	}
+	return letters > 0
+}
@@ -0,0 +1,90 @@
+package transliteration
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestFinglishCandidates(t *testing.T) {
+	tests := []struct {
+		word    string
+		persian string
+	}{
+		{word: "khiaban", persian: "خیابان"},
+		{word: "azadi", persian: "آزادی"},
+		{word: "Tehran", persian: "تهران"},
+		{word: "mohammad", persian: "محمد"},
+		{word: "khaneh", persian: "خانه"},
+		{word: "enghelab", persian: "انقلاب"},
+	}
+	finglish := NewFinglish()
+	for _, test := range tests {
+		candidates := finglish.Candidates(test.word)
+		if !slices.Contains(candidates, test.persian) {
+			t.Errorf("Expected '%s' among the candidates of '%s', got %v", test.persian, test.word, candidates)
+		}
+		if len(candidates) > DefaultMaxCandidates {
+			t.Errorf("Expected at most %d candidates for '%s', got %d", DefaultMaxCandidates, test.word, len(candidates))
+		}
+	}
+	if candidates := finglish.Candidates(""); len(candidates) != 0 {
+		t.Errorf("Expected no candidate for an empty word, got %v", candidates)
+	}
+}
+
+func TestLoadRules(t *testing.T) {
+	transliterator := New()
+	rules := `
+# whole words take precedence
+^valiasr$	ولیعصر
+v	و
+a	ا
+a
+l	ل
+i	ی
+s	س
+s	ص
+r	ر
+`
+	if err := transliterator.LoadRules(strings.NewReader(rules)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if candidates := transliterator.Candidates("valiasr"); !slices.Equal(candidates, []string{"ولیعصر"}) {
+		t.Errorf("Expected the whole-word pair only, got %v", candidates)
+	}
+	if candidates := transliterator.Candidates("vas"); !slices.Equal(candidates, []string{"واس", "واص", "وس", "وص"}) {
+		t.Errorf("Expected the candidates in order of preference, got %v", candidates)
+	}
+	if candidates := transliterator.Candidates("vax"); len(candidates) != 0 {
+		t.Errorf("Expected no candidate for a sequence without pair, got %v", candidates)
+	}
+
+	// Pairs loaded later are preferred
+	if err := transliterator.LoadRules(strings.NewReader("s\tث")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if candidates := transliterator.Candidates("s"); !slices.Equal(candidates, []string{"ث", "س", "ص"}) {
+		t.Errorf("Expected the loaded pair first, got %v", candidates)
+	}
+
+	for _, invalid := range []string{"a b c", "^$\tا"} {
+		if err := New().LoadRules(strings.NewReader(invalid)); err == nil {
+			t.Errorf("Expected error for line %q", invalid)
+		}
+	}
+
+	transliterator.MaxCandidates = 2
+	if candidates := transliterator.Candidates("vas"); len(candidates) != 2 {
+		t.Errorf("Expected MaxCandidates candidates, got %v", candidates)
+	}
+}
+
+func TestIsLatin(t *testing.T) {
+	tests := map[string]bool{"khiaban": true, "Sa'di": true, "خیابان": false, "b12": false, "'": false, "": false}
+	for word, want := range tests {
+		if got := IsLatin(word); got != want {
+			t.Errorf("IsLatin(%q) = %v, want %v", word, got, want)
+		}
+	}
+}
@@ -1,60 +1,139 @@
 package symspell
 
 import (
+	"errors"
+	"io"
+	"io/fs"
 	"log"
 
 	"github.com/snapp-incubator/go-symspell/internal"
 	"github.com/snapp-incubator/go-symspell/pkg/items"
+	"github.com/snapp-incubator/go-symspell/pkg/loadresult"
 	"github.com/snapp-incubator/go-symspell/pkg/options"
 	"github.com/snapp-incubator/go-symspell/pkg/verbosity"
 )
 
+// NewSymSpell calls log.Fatal when the options are invalid, use New to handle the error instead.
 func NewSymSpell(opt ...options.Options) SymSpell {
-	symspell, err := internal.NewSymSpell(opt...)
+	symspell, err := New(opt...)
 	if err != nil {
 		log.Fatal("[ERROR] ", err)
 	}
 	return symspell
 }
 
+// New creates a SymSpell and returns an error when the options are invalid.
+func New(opt ...options.Options) (SymSpell, error) {
+	symspell, err := internal.NewSymSpell(opt...)
+	if err != nil {
+		return nil, err
+	}
+	return symspell, nil
+}
+
 // NewSymSpellWithLoadDictionary used when want Lookup only
 func NewSymSpellWithLoadDictionary(dirPath string, termIndex, countIndex int, opt ...options.Options) SymSpell {
-	symspell := NewSymSpell(opt...)
-	ok, err := symspell.LoadDictionary(dirPath, termIndex, countIndex, " ")
+	symspell, _, err := NewWithLoadDictionary(dirPath, termIndex, countIndex, opt...)
 	if err != nil {
 		log.Fatal("[Error] ", err)
 	}
-	if !ok {
-		log.Fatal("[Error] loading dictionary has been failed")
-	}
 	return symspell
 }
 
+// NewWithLoadDictionary creates a SymSpell with a unigram dictionary, it returns an error instead of
+// exiting when the options are invalid or the dictionary cannot be loaded.
+func NewWithLoadDictionary(dirPath string, termIndex, countIndex int, opt ...options.Options) (SymSpell, loadresult.Result, error) {
+	var result loadresult.Result
+	symspell, err := internal.NewSymSpell(opt...)
+	if err != nil {
+		return nil, result, err
+	}
+	dictionary, err := symspell.LoadDictionaryWithResult(dirPath, termIndex, countIndex, " ")
+	result.Add(dictionary)
+	if err != nil {
+		return nil, result, err
+	}
+	return symspell, result, nil
+}
+
 func NewSymSpellWithLoadBigramDictionary(vocabDirPath, bigramDirPath, exactDirPath string, termIndex, countIndex int, opt ...options.Options) SymSpell {
-	symspell := NewSymSpell(opt...)
-	ok, err := symspell.LoadDictionary(vocabDirPath, termIndex, countIndex, " ")
-	if err != nil || !ok {
+	symspell, result, err := NewWithLoadBigramDictionary(vocabDirPath, bigramDirPath, exactDirPath, termIndex, countIndex, opt...)
+	if symspell == nil {
 		log.Fatal("[Error] ", err)
 	}
-	ok, err = symspell.LoadBigramDictionary(bigramDirPath, termIndex, countIndex+1, "")
-	if err != nil || !ok {
-		if bigramDirPath != "" {
-			log.Println("[Error] ", err)
+	for _, dictionary := range result.Dictionaries {
+		if dictionary.Err != nil {
+			log.Println("[Error] ", dictionary.Err)
 		}
 	}
+	return symspell
+}
+
+// NewWithLoadBigramDictionary creates a SymSpell with unigram, bigram and exact dictionaries.
+// Empty bigram or exact paths are ignored. The returned SymSpell is nil only when the options or the
+// unigram dictionary are invalid, failures of the bigram and exact dictionaries are reported in the
+// result and the returned error while the SymSpell stays usable.
+func NewWithLoadBigramDictionary(
+	vocabDirPath, bigramDirPath, exactDirPath string,
+	termIndex, countIndex int,
+	opt ...options.Options,
+) (SymSpell, loadresult.Result, error) {
+	var result loadresult.Result
+	symspell, err := internal.NewSymSpell(opt...)
+	if err != nil {
+		return nil, result, err
+	}
+	dictionary, err := symspell.LoadDictionaryWithResult(vocabDirPath, termIndex, countIndex, " ")
+	result.Add(dictionary)
+	if err != nil {
+		return nil, result, err
+	}
+	var errs []error
+	if bigramDirPath != "" {
+		dictionary, err = symspell.LoadBigramDictionaryWithResult(bigramDirPath, termIndex, countIndex+1, "")
+		result.Add(dictionary)
+		errs = append(errs, err)
+	}
 	if exactDirPath != "" {
-		ok, err = symspell.LoadExactDictionary(exactDirPath, " ")
-		if err != nil || !ok {
-			log.Println("[Error] ", err)
-		}
+		dictionary, err = symspell.LoadExactDictionaryWithResult(exactDirPath, " ")
+		result.Add(dictionary)
+		errs = append(errs, err)
 	}
-	return symspell
+	return symspell, result, errors.Join(errs...)
+}
+
+// NewWithSnapshot creates a SymSpell from a snapshot written by SaveSnapshot, skipping the generation of
+// deletes. The options must use the same prefix length and max dictionary edit distance as the snapshot.
+func NewWithSnapshot(snapshot io.Reader, opt ...options.Options) (SymSpell, error) {
+	symspell, err := internal.NewSymSpell(opt...)
+	if err != nil {
+		return nil, err
+	}
+	if err = symspell.LoadSnapshot(snapshot); err != nil {
+		return nil, err
+	}
+	return symspell, nil
 }
 
 type SymSpell interface {
 	Lookup(phrase string, verbosity verbosity.Verbosity, maxEditDistance int) ([]items.SuggestItem, error)
+	LookupInContext(left, word, right string, verbosity verbosity.Verbosity, maxEditDistance int) ([]items.SuggestItem, error)
 	LookupCompound(phrase string, maxEditDistance int) *items.SuggestItem
+	LookupCompoundTopK(phrase string, k int, verbosity verbosity.Verbosity, maxEditDistance int) []items.SuggestItem
+	WordSegmentation(phrase string, maxEditDistance int, maxSegmentationWordLength int) items.Composition
+	CorrectText(text string, maxEditDistance int) items.TextCorrection
 	LoadBigramDictionary(corpusPath string, termIndex, countIndex int, separator string) (bool, error)
 	LoadDictionary(corpusPath string, termIndex int, countIndex int, separator string) (bool, error)
 	LoadExactDictionary(corpusPath string, separator string) (bool, error)
+	LoadBigramDictionaryStream(corpusStream io.Reader, termIndex, countIndex int, separator string) (bool, error)
+	LoadDictionaryStream(corpusStream io.Reader, termIndex int, countIndex int, separator string) (bool, error)
+	LoadExactDictionaryStream(corpusStream io.Reader, separator string) (bool, error)
+	LoadBigramDictionaryFS(fsys fs.FS, corpusPath string, termIndex, countIndex int, separator string) (bool, error)
+	LoadDictionaryFS(fsys fs.FS, corpusPath string, termIndex int, countIndex int, separator string) (bool, error)
+	LoadExactDictionaryFS(fsys fs.FS, corpusPath string, separator string) (bool, error)
+	CreateDictionaryEntry(term string, count int) bool
+	DeleteDictionaryEntry(term string) bool
+	IncrementCount(term string, delta int) bool
+	SaveSnapshot(w io.Writer) error
+	LoadSnapshot(r io.Reader) error
 }
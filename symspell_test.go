@@ -1,13 +1,22 @@
 package symspell
 
 import (
+	"embed"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/snapp-incubator/go-symspell/pkg/loadresult"
 	"github.com/snapp-incubator/go-symspell/pkg/options"
+	"github.com/snapp-incubator/go-symspell/pkg/transliteration"
 	"github.com/snapp-incubator/go-symspell/pkg/verbosity"
 )
 
+//go:embed internal/tests/exact.txt
+var exactFS embed.FS
+
 func TestSymspellLookup(t *testing.T) {
 	type args struct {
 		a               string
@@ -273,3 +282,131 @@ func TestSymspellLookupCompoundUnigram(t *testing.T) {
 		})
 	}
 }
+
+func TestSymspellWordSegmentation(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		want string
+	}{
+		{
+			name: "street and plate",
+			a:    "خیابانآزادیپلاک۱۲",
+			want: "خیابان آزادی پلاک ۱۲",
+		},
+		{
+			name: "square",
+			a:    "میدانازادی",
+			want: "میدان ازادی",
+		},
+	}
+	symSpell := NewSymSpellWithLoadDictionary("internal/tests/vocab_fa.txt", 0, 1,
+		options.WithCountThreshold(10),
+		options.WithMaxDictionaryEditDistance(3),
+		options.WithPrefixLength(5),
+	)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := symSpell.WordSegmentation(tt.a, 1, 12)
+			if result.CorrectedString != tt.want {
+				t.Errorf("got = %v, want %v", result.CorrectedString, tt.want)
+			}
+		})
+	}
+}
+
+func TestSymspellLookupCompoundFinglish(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		want string
+	}{
+		{
+			name: "street",
+			a:    "khiaban azadi",
+			want: "خیابان آزادی",
+		},
+		{
+			name: "mixed scripts",
+			a:    "khiaban آزادی",
+			want: "خیابان آزادی",
+		},
+	}
+	symSpell := NewSymSpellWithLoadDictionary("internal/tests/vocab_fa.txt", 0, 1,
+		options.WithCountThreshold(10),
+		options.WithMaxDictionaryEditDistance(3),
+		options.WithPrefixLength(5),
+		options.WithTransliterator(transliteration.NewFinglish()),
+	)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suggest := symSpell.LookupCompound(tt.a, 2)
+			if suggest.Term != tt.want || !suggest.Transliterated {
+				t.Errorf("got = %v, want transliterated %v", suggest, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewReturnsError(t *testing.T) {
+	if _, err := New(options.WithPrefixLength(2), options.WithMaxDictionaryEditDistance(3)); err == nil {
+		t.Errorf("expected an error for invalid options")
+	}
+	symSpell, result, err := NewWithLoadDictionary("internal/tests/not_found.txt", 0, 1)
+	if err == nil || symSpell != nil {
+		t.Errorf("expected an error for a missing dictionary")
+	}
+	if result.Loaded(loadresult.Unigram) {
+		t.Errorf("expected the unigram dictionary not to be loaded")
+	}
+}
+
+func TestNewWithLoadBigramDictionaryResult(t *testing.T) {
+	dir := t.TempDir()
+	vocabPath := filepath.Join(dir, "vocab.txt")
+	bigramPath := filepath.Join(dir, "bigram.txt")
+	if err := os.WriteFile(vocabPath, []byte("خیابان 100\nمیدان x\nآزادی\nکارگر 20\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(bigramPath, []byte("خیابان کارگر 10\n\nمیدان\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	symSpell, result, err := NewWithLoadBigramDictionary(vocabPath, bigramPath, filepath.Join(dir, "exact.txt"), 0, 1)
+	if symSpell == nil {
+		t.Fatalf("expected a usable SymSpell, got error %v", err)
+	}
+	if err == nil {
+		t.Errorf("expected an error for the missing exact dictionary")
+	}
+	if len(result.Dictionaries) != 3 {
+		t.Fatalf("expected 3 dictionary results, got %d", len(result.Dictionaries))
+	}
+	unigram, bigram, exact := result.Dictionaries[0], result.Dictionaries[1], result.Dictionaries[2]
+	if !unigram.Loaded || unigram.Accepted != 2 || unigram.Skipped != 2 ||
+		unigram.Reasons[loadresult.InvalidCount] != 1 || unigram.Reasons[loadresult.MissingFields] != 1 {
+		t.Errorf("unexpected unigram result %+v", unigram)
+	}
+	if !bigram.Loaded || bigram.Accepted != 1 || bigram.Reasons[loadresult.EmptyLine] != 1 ||
+		bigram.Reasons[loadresult.MissingFields] != 1 {
+		t.Errorf("unexpected bigram result %+v", bigram)
+	}
+	if exact.Loaded || exact.Err == nil {
+		t.Errorf("unexpected exact result %+v", exact)
+	}
+}
+
+func TestSymspellLoadEmbeddedExactDictionary(t *testing.T) {
+	symSpell := NewSymSpell(options.WithSplitWordBySpace())
+	ok, err := symSpell.LoadExactDictionaryFS(exactFS, "internal/tests/exact.txt", " ")
+	if !ok || err != nil {
+		t.Fatalf("expected embedded dictionary to load, got %v", err)
+	}
+	ok, err = symSpell.LoadDictionaryStream(strings.NewReader("میدان 100\nازادی 50\n"), 0, 1, " ")
+	if !ok || err != nil {
+		t.Fatalf("expected dictionary stream to load, got %v", err)
+	}
+	if got := symSpell.LookupCompound("م ازادی", 2).Term; got != "میدان ازادی" {
+		t.Errorf("got = %v, want %v", got, "میدان ازادی")
+	}
+}